@@ -0,0 +1,62 @@
+package csidh
+
+import (
+	"errors"
+	"io"
+)
+
+var errBaseKey = errors.New("csidh: invalid base public key")
+
+// BaseContext holds data about a public key that is reused by every group
+// action evaluated on it with ActionWithBase: the result of its validation,
+// and a point on the curve for the first round of the action. It only holds
+// public data, so it can be shared by several private keys, and it can be
+// used concurrently.
+type BaseContext struct {
+	pub   PublicKey
+	first fp
+}
+
+// PrepareBase validates pub and samples a point on its curve, returning a
+// BaseContext for evaluating group actions on pub. The parameter set params
+// must be CSIDH512; nil selects it. It returns an error if pub is not a
+// valid public key.
+func PrepareBase(pub *PublicKey, params *ParamSet, rng io.Reader) (*BaseContext, error) {
+	if params != nil && params.ID != ParamsCSIDH512 {
+		return nil, errParamsUnsupported(params.ID)
+	}
+	if pub == nil || !Validate(pub, rng) {
+		return nil, errBaseKey
+	}
+
+	b := &BaseContext{pub: *pub}
+	var gen fpRngGen
+	for {
+		var rhs fp
+		gen.randFp(&b.first, rng)
+		montEval(&rhs, &b.pub.a, &b.first)
+		if rhs.isNonQuadRes() == 0 {
+			return b, nil
+		}
+	}
+}
+
+// ActionWithBase computes the shared secret between prv and the public key
+// of base, as DeriveSecret does, and writes it to out. The validation of the
+// public key and the sampling of the first point of the action are done
+// once by PrepareBase, so they are amortized over all the calls.
+func ActionWithBase(out *[SharedSecretSize]byte, prv *PrivateKey, base *BaseContext, rng io.Reader) {
+	var s actionState
+	// The first point lies on the base curve, so the radical isogenies,
+	// which change the curve, are evaluated after the others. This does
+	// not change the result, as the class group is commutative.
+	e := prv.exponents()
+	rad := radicalExps(&e)
+	s.scheduleExps(&e)
+	s.first = &base.first
+	a := base.pub.a
+	s.run(&a, &prv.fpRngGen, rng)
+	radicalAction(&a, &rad, &prv.fpRngGen, rng, nil)
+	pub := PublicKey{a: a}
+	pub.Export(out[:])
+}
@@ -0,0 +1,96 @@
+package csidh
+
+import (
+	"errors"
+	"io"
+)
+
+var (
+	errBlindInput = errors.New("csidh: invalid public key to blind")
+	errBlindState = errors.New("csidh: blinding state already used")
+)
+
+// BlindState holds the blinding element of the class group chosen by Blind,
+// which Unblind needs to remove it. It must be kept secret by the client,
+// and it can be used only once.
+type BlindState struct {
+	b    PrivateKey
+	used bool
+}
+
+// Blind starts the oblivious evaluation of the action of a server's secret
+// key on the curve input. It returns the action of a fresh random element b
+// of the class group on input, which is sent to the server instead of
+// input, and the state that Unblind needs to remove b from the server's
+// answer. The parameter set params must be CSIDH512; nil selects it. The
+// input is validated, and the rng is used to sample b and the points
+// needed by the group action.
+//
+// Since the action is commutative, the server's answer to the blinded curve
+// is b*k*input, and Unblind applies b^-1 to get k*input. The blinded curve
+// is distributed as a public key generated with GeneratePrivateKey, so it
+// does not reveal input to the server, nor links two blindings of the same
+// curve, under the assumption that such keys are close to uniform in the
+// class group. This is only secure against a semi-honest server: the client
+// cannot verify that the server used its committed key, as a verifiable
+// OPRF would allow.
+func Blind(input *PublicKey, rng io.Reader, params *ParamSet) (*PublicKey, *BlindState, error) {
+	if params != nil && params.ID != ParamsCSIDH512 {
+		return nil, nil, errParamsUnsupported(params.ID)
+	}
+	if input == nil || !Validate(input, rng) {
+		return nil, nil, errBlindInput
+	}
+	state := new(BlindState)
+	if err := GeneratePrivateKey(&state.b, rng); err != nil {
+		return nil, nil, err
+	}
+	blinded := &PublicKey{a: input.a}
+	groupAction(blinded, &state.b, rng)
+	return blinded, state, nil
+}
+
+// Evaluate is the server's step of the oblivious evaluation started by
+// Blind: it returns the action of priv on the blinded curve. The blinded
+// curve comes from an untrusted client, so it is validated first, as in
+// DeriveSecret, and an error is returned if it is not a valid public key.
+func Evaluate(priv *PrivateKey, blinded *PublicKey, rng io.Reader) (*PublicKey, error) {
+	if blinded == nil || !Validate(blinded, rng) {
+		return nil, errBaseKey
+	}
+	out := &PublicKey{a: blinded.a}
+	groupAction(out, priv, rng)
+	return out, nil
+}
+
+// Unblind finishes the oblivious evaluation: it removes the blinding element
+// of state from the server's answer, returning the action of the server's
+// key on the curve given to Blind. The answer is validated first. The state
+// is erased, and any later use of it returns an error.
+func Unblind(state *BlindState, evaluated *PublicKey, rng io.Reader) (*PublicKey, error) {
+	if state == nil || state.used {
+		return nil, errBlindState
+	}
+	if evaluated == nil || !Validate(evaluated, rng) {
+		return nil, errBaseKey
+	}
+	var inv PrivateKey
+	state.b.invert(&inv)
+	state.b = PrivateKey{}
+	state.used = true
+
+	out := &PublicKey{a: evaluated.a}
+	groupAction(out, &inv, rng)
+	inv = PrivateKey{}
+	return out, nil
+}
+
+// invert sets inv to the inverse of c in the class group, whose exponents
+// are the negated exponents of c.
+func (c *PrivateKey) invert(inv *PrivateKey) {
+	e := c.exponents()
+	for i := range e {
+		e[i] = -e[i]
+	}
+	inv.setExponents(&e)
+}
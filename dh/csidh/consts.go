@@ -94,6 +94,33 @@ var (
 		0xFE455868AF1F2625, 0x32DA4747BA07C4DF,
 	}
 
+	// (p+1)/4. Used as exponent for square roots, hence not in
+	// montgomery domain
+	pPlus1By4 = fp{
+		0x46E06E414CF1B21F, 0x709C86FD15EB2A0D,
+		0xD459CC3307C2D3C9, 0x69EAB1B159FCD541,
+		0x96BEFF31A4C8B273, 0xAD0B420EBB722310,
+		0xFF22AC34578F9312, 0x196D23A3DD03E26F,
+	}
+
+	// (2p-1)/3, the inverse of 3 mod p-1. Used as exponent for cube
+	// roots, hence not in montgomery domain
+	cbrtExp = fp{
+		0x67ABD0AE228485A7, 0xD6F6BD4D8FC87023,
+		0x8B9A208814B234C3, 0xC51C84839AA238AF,
+		0xE752A88462173133, 0x781E057C9E85B2D6,
+		0xFDB1CB363ED432DC, 0x43CDB45FA2B5067F,
+	}
+
+	// The inverse of 5 mod p-1. Used as exponent for fifth roots, hence
+	// not in montgomery domain
+	fifthRootExp = fp{
+		0xAA1AA236524411E3, 0xA7DE10C5CE3464EC,
+		0xFDA4507A7906C916, 0x316677433E5ECC9D,
+		0x36973143F1E1AC48, 0x05B49E89C1DEBA8E,
+		0x97866A17388BC760, 0x3D05EF22DF3C85D9,
+	}
+
 	// p-1 mod 2^64. Used as exponent, hence not
 	// in montgomery domain
 	pMin1 = fp{
@@ -1,7 +1,11 @@
 package csidh
 
 import (
+	"crypto/subtle"
 	"io"
+	"time"
+
+	"golang.org/x/crypto/sha3"
 )
 
 // 511-bit number representing prime field element GF(p)
@@ -115,92 +119,225 @@ func cofactorMul(p *point, a *coeff, halfL, halfR int, order *fp) (bool, bool) {
 	return d1 || d2, r1 || r2
 }
 
-// groupAction evaluates group action of prv.e on a Montgomery
-// curve represented by coefficient pub.A.
-// This is implementation of algorithm 2 from ia.cr/2018/383.
-func groupAction(pub *PublicKey, prv *PrivateKey, rng io.Reader) {
-	var k [2]fp
-	var e [2][primeCount]uint8
-	done := [2]bool{false, false}
-	A := coeff{a: pub.a, c: one}
+// actionState holds the state of the evaluation of the group action on a
+// single curve.
+type actionState struct {
+	A    coeff
+	k    [2]fp
+	e    [2][primeCount]uint16
+	done [2]bool
+	// first, if not nil, is the x-coordinate of a point on the initial
+	// curve (not on its twist) used by the first round, see BaseContext.
+	first *fp
+	// isoTime, if not nil, accumulates the time spent on the isogenies of
+	// each degree, indexed as primes.
+	isoTime *[primeCount]time.Duration
+}
 
-	k[0][0] = 4
-	k[1][0] = 4
+// scheduleExps initializes the exponents and the cofactors of s from e.
+func (s *actionState) scheduleExps(e *[primeCount]int16) {
+	s.k[0] = fp{4}
+	s.k[1] = fp{4}
+	s.done = [2]bool{false, false}
 
 	for i, v := range primes {
-		t := (prv.e[uint(i)>>1] << ((uint(i) % 2) * 4)) >> 4
+		t := e[i]
 		if t > 0 {
-			e[0][i] = uint8(t)
-			e[1][i] = 0
-			mul512(&k[1], &k[1], v)
+			s.e[0][i] = uint16(t)
+			s.e[1][i] = 0
+			mul512(&s.k[1], &s.k[1], v)
 		} else if t < 0 {
-			e[1][i] = uint8(-t)
-			e[0][i] = 0
-			mul512(&k[0], &k[0], v)
+			s.e[1][i] = uint16(-t)
+			s.e[0][i] = 0
+			mul512(&s.k[0], &s.k[0], v)
 		} else {
-			e[0][i] = 0
-			e[1][i] = 0
-			mul512(&k[0], &k[0], v)
-			mul512(&k[1], &k[1], v)
+			s.e[0][i] = 0
+			s.e[1][i] = 0
+			mul512(&s.k[0], &s.k[0], v)
+			mul512(&s.k[1], &s.k[1], v)
 		}
 	}
+}
 
-	for {
-		var P point
-		var rhs fp
-		prv.randFp(&P.x, rng)
-		P.z = one
-		montEval(&rhs, &A.a, &P.x)
-		sign := rhs.isNonQuadRes()
-
-		if done[sign] {
-			continue
+// finished returns true once all the isogenies have been evaluated.
+func (s *actionState) finished() bool { return s.done[0] && s.done[1] }
+
+// round samples a random point and evaluates as many isogenies as its order
+// allows. Afterwards s.A is left in projective form.
+func (s *actionState) round(gen *fpRngGen, rng io.Reader) {
+	var P point
+	var sign int
+	if s.first != nil {
+		P.x, P.z = *s.first, one
+		s.first = nil
+	} else {
+		for {
+			var rhs fp
+			gen.randFp(&P.x, rng)
+			P.z = one
+			montEval(&rhs, &s.A.a, &P.x)
+			sign = rhs.isNonQuadRes()
+			if !s.done[sign] {
+				break
+			}
 		}
+	}
 
-		xMul(&P, &P, &A, &k[sign])
-		done[sign] = true
+	// The cofactors depend on the private key, so the multiplications use
+	// the ladder with public bounds on their bit lengths. Note that k < p+1.
+	A, e, k := &s.A, &s.e[sign], &s.k[sign]
+	ladderMul(&P, &P, A, k, pbits)
+	s.done[sign] = true
 
-		for i, v := range primes {
-			if e[sign][i] != 0 {
-				cof := fp{1}
-				var K point
+	for i, v := range primes {
+		if e[i] != 0 {
+			cof := fp{1}
+			var K point
 
-				for j := i + 1; j < len(primes); j++ {
-					if e[sign][j] != 0 {
-						mul512(&cof, &cof, primes[j])
-					}
+			for j := i + 1; j < len(primes); j++ {
+				if e[j] != 0 {
+					mul512(&cof, &cof, primes[j])
 				}
+			}
 
-				xMul(&K, &P, &A, &cof)
-				if !K.z.isZero() {
-					xIso(&P, &A, &K, v)
-					e[sign][i] = e[sign][i] - 1
-					if e[sign][i] == 0 {
-						mul512(&k[sign], &k[sign], primes[i])
-					}
+			ladderMul(&K, &P, A, &cof, cofactorBits[i])
+			if !K.z.isZero() {
+				if s.isoTime != nil {
+					start := time.Now()
+					xIso(&P, A, &K, v)
+					s.isoTime[i] += time.Since(start)
+				} else {
+					xIso(&P, A, &K, v)
+				}
+				e[i] = e[i] - 1
+				if e[i] == 0 {
+					mul512(k, k, primes[i])
 				}
 			}
-			done[sign] = done[sign] && (e[sign][i] == 0)
 		}
+		s.done[sign] = s.done[sign] && (e[i] == 0)
+	}
+}
 
-		modExpRdc512(&A.c, &A.c, &pMin1)
-		mulRdc(&A.a, &A.a, &A.c)
-		A.c = one
+// groupAction evaluates group action of prv.e on a Montgomery
+// curve represented by coefficient pub.A.
+// This is implementation of algorithm 2 from ia.cr/2018/383, except that
+// the isogenies of the smallest primes are evaluated by radicalAction.
+func groupAction(pub *PublicKey, prv *PrivateKey, rng io.Reader) {
+	groupActionTimed(pub, prv, rng, nil)
+}
+
+// groupActionTimed is as groupAction. If isoTime is not nil, the time spent
+// on the isogenies of each degree is added to it, indexed as primes.
+func groupActionTimed(pub *PublicKey, prv *PrivateKey, rng io.Reader, isoTime *[primeCount]time.Duration) {
+	s := actionState{isoTime: isoTime}
+	e := prv.exponents()
+	rad := radicalExps(&e)
+	radicalAction(&pub.a, &rad, &prv.fpRngGen, rng, isoTime)
+	s.scheduleExps(&e)
+	s.run(&pub.a, &prv.fpRngGen, rng)
+}
 
-		if done[0] && done[1] {
+// run evaluates the action scheduled in s on the curve with coefficient a,
+// which is updated with the resulting curve.
+func (s *actionState) run(a *fp, gen *fpRngGen, rng io.Reader) {
+	s.A = coeff{a: *a, c: one}
+	for !s.finished() {
+		s.round(gen, rng)
+		modExpRdc512(&s.A.c, &s.A.c, &pMin1)
+		mulRdc(&s.A.a, &s.A.a, &s.A.c)
+		s.A.c = one
+	}
+	*a = s.A.a
+}
+
+// ActionBatch evaluates the group action of prv on each of the curves in
+// bases, and returns the resulting curves in the same order. The i-th output
+// is the curve DeriveSecret would compute from bases[i], except that the keys
+// in bases are not validated. Keys in bases are not modified.
+// The exponents are decoded once for the whole batch, and curves are
+// processed in lockstep so that the field inversions of each round are
+// batched with Montgomery's trick. The isogenies of the smallest primes
+// are evaluated by radicalAction on each curve beforehand.
+func ActionBatch(prv *PrivateKey, bases []*PublicKey, rng io.Reader) []*PublicKey {
+	var sched actionState
+	e := prv.exponents()
+	rad := radicalExps(&e)
+	sched.scheduleExps(&e)
+
+	states := make([]actionState, len(bases))
+	for i := range states {
+		states[i] = sched
+		states[i].A = coeff{a: bases[i].a, c: one}
+		r := rad
+		radicalAction(&states[i].A.a, &r, &prv.fpRngGen, rng, nil)
+	}
+
+	active := make([]*actionState, 0, len(states))
+	prods := make([]fp, len(states))
+	for {
+		active = active[:0]
+		for i := range states {
+			if !states[i].finished() {
+				states[i].round(&prv.fpRngGen, rng)
+				active = append(active, &states[i])
+			}
+		}
+		if len(active) == 0 {
 			break
 		}
+
+		// Montgomery's trick: invert all the c coefficients at once.
+		prods[0] = active[0].A.c
+		for i := 1; i < len(active); i++ {
+			mulRdc(&prods[i], &prods[i-1], &active[i].A.c)
+		}
+		var inv fp
+		modExpRdc512(&inv, &prods[len(active)-1], &pMin1)
+		for i := len(active) - 1; i >= 0; i-- {
+			var cInv fp
+			if i > 0 {
+				mulRdc(&cInv, &inv, &prods[i-1])
+				mulRdc(&inv, &inv, &active[i].A.c)
+			} else {
+				cInv = inv
+			}
+			mulRdc(&active[i].A.a, &active[i].A.a, &cInv)
+			active[i].A.c = one
+		}
 	}
-	pub.a = A.a
+
+	out := make([]*PublicKey, len(states))
+	for i := range states {
+		out[i] = &PublicKey{a: states[i].A.a}
+	}
+	return out
 }
 
 // PrivateKey operations
 
+// exponents returns the exponents of the private key unpacked.
+func (c *PrivateKey) exponents() (e [primeCount]int16) {
+	for i := range e {
+		e[i] = int16((c.e[uint(i)>>1] << ((uint(i) % 2) * 4)) >> 4)
+	}
+	return
+}
+
+// setExponents packs the exponents e into the private key. It is the
+// inverse of exponents, so each exponent must be in [-8, 7].
+func (c *PrivateKey) setExponents(e *[primeCount]int16) {
+	c.e = [PrivateKeySize]int8{}
+	for i := range e {
+		c.e[i>>1] |= int8((uint8(e[i]) & 0xF) << uint((1-i%2)*4))
+	}
+}
+
 func (c *PrivateKey) Import(key []byte) bool {
 	if len(key) < len(c.e) {
 		return false
 	}
-	for i, v := range key {
+	for i, v := range key[:len(c.e)] {
 		c.e[i] = int8(v)
 	}
 	return true
@@ -216,6 +353,25 @@ func (c PrivateKey) Export(out []byte) bool {
 	return true
 }
 
+// Equal reports whether c and other hold the same exponents. It runs in
+// constant time, and returns false if either key is nil. Private keys are
+// not tagged with a parameter set, so there is no parameter set to compare.
+func (c *PrivateKey) Equal(other *PrivateKey) bool {
+	if c == nil || other == nil {
+		return false
+	}
+	var a, b [PrivateKeySize]byte
+	c.Export(a[:])
+	other.Export(b[:])
+	return subtle.ConstantTimeCompare(a[:], b[:]) == 1
+}
+
+// GeneratePrivateKey generates a private key whose exponents are sampled
+// uniformly from [-5, 5]. To rotate a key pair, e.g., for forward secrecy,
+// generate a new one with GeneratePrivateKey and GeneratePublicKey. As the
+// exponents are bounded, composing the old key with a random element of
+// the class group would have to cancel it to stay in range, which gives the
+// same distribution.
 func GeneratePrivateKey(key *PrivateKey, rng io.Reader) error {
 	for i := range key.e {
 		key.e[i] = 0
@@ -240,6 +396,43 @@ func GeneratePrivateKey(key *PrivateKey, rng io.Reader) error {
 	return nil
 }
 
+// deriveTries is the number of candidate bytes read for each exponent by
+// DerivePrivateKey. A candidate is rejected with probability 3/256.
+const deriveTries = 8
+
+// DerivePrivateKey deterministically derives a private key from a master
+// secret and a domain string. The master secret is expanded with cSHAKE256,
+// using the domain as customization string, so that keys derived for
+// different domains are independent. Each exponent is sampled from the
+// expanded output by constant-time rejection sampling.
+func DerivePrivateKey(key *PrivateKey, master []byte, domain string) {
+	var buf [primeCount * deriveTries]byte
+	h := sha3.NewCShake256(nil, []byte(domain))
+	_, _ = h.Write(master)
+	_, _ = h.Read(buf[:])
+
+	for i := range key.e {
+		key.e[i] = 0
+	}
+
+	for i := 0; i < primeCount; i++ {
+		// Choose the first candidate smaller than 253 = 11*23, so that
+		// reducing it modulo 11 is unbiased. If none is found (probability
+		// lower than 2^-51), the last candidate is used.
+		var v, found uint8
+		for _, b := range buf[i*deriveTries : (i+1)*deriveTries] {
+			ok := uint8(subtle.ConstantTimeLessOrEq(int(b), 252)) &^ found
+			v = uint8(subtle.ConstantTimeSelect(int(ok|(1-found)), int(b), int(v)))
+			found |= ok
+		}
+		t := int8(v%uint8(2*expMax+1)) - expMax
+		key.e[i>>1] |= int8((uint8(t) & 0xF) << uint((i%2)*4))
+	}
+	for i := range buf {
+		buf[i] = 0
+	}
+}
+
 // Public key operations
 
 // reset removes key material from PublicKey.
@@ -320,6 +513,32 @@ func Validate(pub *PublicKey, rng io.Reader) bool {
 	}
 }
 
+// ValidateClass is a stricter version of Validate, meant for keys from
+// untrusted sources. Besides checking that the curve y^2 = x^3 + c.a*x^2 + x
+// is supersingular, i.e., that it has p+1 rational points, it checks that
+// its group of rational points is cyclic, i.e., that the only rational
+// point of order 2 is (0,0), which holds if and only if c.a^2-4 is not a
+// square. Since p = 3 mod 8, these are the curves with Fp-endomorphism ring
+// Z[sqrt(-p)], on which the class group acts transitively, so they are all
+// reachable from the base curve. Curves defined over Fp2 but not over Fp
+// cannot be encoded as public keys. A Montgomery curve with full rational
+// 2-torsion has a rational point of order 4 on itself and on its twist, so
+// 8 divides the order of both, while p+1 = 4 mod 8: Validate already
+// rejects such curves, and the extra check only guards against a faulty
+// Validate. It costs one modular exponentiation, which is negligible
+// compared to Validate. The parameter set params must be CSIDH512; nil
+// selects it. It returns false if params is not supported.
+func (c *PublicKey) ValidateClass(params *ParamSet, rng io.Reader) bool {
+	if (params != nil && params.ID != ParamsCSIDH512) || !Validate(c, rng) {
+		return false
+	}
+	var disc, four fp
+	addRdc(&four, &two, &two)
+	mulRdc(&disc, &c.a, &c.a)
+	subRdc(&disc, &disc, &four)
+	return disc.isNonQuadRes() == 1
+}
+
 // DeriveSecret computes a cSIDH shared secret. If successful, returns true
 // and fills 'out' with shared secret. Function returns false in case 'pub' is invalid.
 // More precisely, shared secret is a Montgomery coefficient A of a secret
@@ -333,3 +552,36 @@ func DeriveSecret(out *[64]byte, pub *PublicKey, prv *PrivateKey, rng io.Reader)
 	pub.Export(out[:])
 	return true
 }
+
+// ConfirmSharedSecrets derives the shared secret between 'prv' and each of
+// the 'peers', and compares it in constant time with the corresponding entry
+// of 'expected'. All peer keys are validated before any secret is derived,
+// and keys failing validation are reported as mismatches, as well as entries
+// without a counterpart in the other slice. Function returns true if every
+// secret matches, otherwise it returns false and the indices of the
+// mismatching entries. Keys in 'peers' are not modified.
+func ConfirmSharedSecrets(prv *PrivateKey, peers []*PublicKey, expected [][SharedSecretSize]byte, rng io.Reader) (allMatch bool, mismatches []int) {
+	n := len(peers)
+	if len(expected) > n {
+		n = len(expected)
+	}
+
+	valid := make([]bool, n)
+	for i := 0; i < len(peers) && i < len(expected); i++ {
+		valid[i] = Validate(peers[i], rng)
+	}
+
+	var ss [SharedSecretSize]byte
+	for i := 0; i < n; i++ {
+		if valid[i] {
+			pub := *peers[i]
+			groupAction(&pub, prv, rng)
+			pub.Export(ss[:])
+			valid[i] = subtle.ConstantTimeCompare(ss[:], expected[i][:]) == 1
+		}
+		if !valid[i] {
+			mismatches = append(mismatches, i)
+		}
+	}
+	return len(mismatches) == 0, mismatches
+}
@@ -6,8 +6,12 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	. "github.com/cloudflare/circl/internal/test"
 )
@@ -101,6 +105,189 @@ func TestPrivateKeyExportImport(t *testing.T) {
 	}
 }
 
+func TestPrivateKeyPack(t *testing.T) {
+	for i := 0; i < 1<<6; i++ {
+		var prv PrivateKey
+		CheckNoErr(t, GeneratePrivateKey(&prv, rng), "PrivateKey generation failed")
+		b := prv.Pack()
+		if len(b) != PackedPrivateKeySize {
+			t.Fatalf("got %v bytes, want %v", len(b), PackedPrivateKeySize)
+		}
+		got, err := UnpackPrivateKey(b, &CSIDH512)
+		CheckNoErr(t, err, "UnpackPrivateKey failed")
+		if got.exponents() != prv.exponents() {
+			t.Fatalf("unpacked exponents %v, want %v", got.exponents(), prv.exponents())
+		}
+	}
+
+	// Every exponent takes expBits bits; 0x5 encodes 0 and 0xB encodes 6.
+	b := bytes.Repeat([]byte{0x55}, PackedPrivateKeySize)
+	want, err := UnpackPrivateKey(b, &CSIDH512)
+	CheckNoErr(t, err, "UnpackPrivateKey failed")
+	for i := 0; i < primeCount; i++ {
+		bad := append([]byte{}, b...)
+		pos := i * expBits
+		bad[pos/8] = bad[pos/8]&^(0xF<<uint(pos%8)) | 0xB<<uint(pos%8)
+		_, err := UnpackPrivateKey(bad, &CSIDH512)
+		CheckIsErr(t, err, "UnpackPrivateKey must fail on out-of-range exponents")
+	}
+	_, err = UnpackPrivateKey(b[:len(b)-1], &CSIDH512)
+	CheckIsErr(t, err, "UnpackPrivateKey must fail on wrong length")
+	params := CSIDH512
+	params.ID = ParamsCSIDH1024
+	_, err = UnpackPrivateKey(b, &params)
+	CheckIsErr(t, err, "UnpackPrivateKey must fail on unsupported parameters")
+	got, err := UnpackPrivateKey(b, nil)
+	CheckNoErr(t, err, "UnpackPrivateKey failed on nil parameters")
+	CheckOk(got.Equal(want), "Nil parameters do not select CSIDH512", t)
+}
+
+func TestPrivateKeyEncodingLength(t *testing.T) {
+	// Keys with every exponent at -m, 0 and m, followed by random keys.
+	var keys []PrivateKey
+	for _, v := range []int8{-expMax, 0, expMax} {
+		var prv PrivateKey
+		for i := range prv.e {
+			prv.e[i] = int8(uint8(v)<<4 | uint8(v)&0xF)
+		}
+		keys = append(keys, prv)
+	}
+	for i := 0; i < numIter; i++ {
+		var prv PrivateKey
+		CheckNoErr(t, GeneratePrivateKey(&prv, rng), "PrivateKey generation failed")
+		keys = append(keys, prv)
+	}
+
+	for _, prv := range keys {
+		b := prv.Pack()
+		if len(b) != PackedPrivateKeySize {
+			t.Fatalf("got %v bytes, want %v", len(b), PackedPrivateKeySize)
+		}
+		got, err := UnpackPrivateKey(b, &CSIDH512)
+		CheckNoErr(t, err, "UnpackPrivateKey failed")
+		if got.e != prv.e {
+			t.Fatalf("unpacked key %v, want %v", got.e, prv.e)
+		}
+
+		// Import ignores the bytes past PrivateKeySize.
+		var buf [PrivateKeySize + 1]byte
+		var prv2 PrivateKey
+		CheckOk(prv.Export(buf[:]), "Export failed", t)
+		CheckOk(prv2.Import(buf[:]), "Import failed", t)
+		if prv2.e != prv.e {
+			t.Fatalf("imported key %v, want %v", prv2.e, prv.e)
+		}
+	}
+}
+
+func TestBlindEvaluate(t *testing.T) {
+	var server, client PrivateKey
+	var input PublicKey
+	CheckNoErr(t, GeneratePrivateKey(&server, rng), "PrivateKey generation failed")
+	CheckNoErr(t, GeneratePrivateKey(&client, rng), "PrivateKey generation failed")
+	GeneratePublicKey(&input, &client, rng)
+	want := ActionBatch(&server, []*PublicKey{&input}, rng)[0]
+
+	blinded, state, err := Blind(&input, rng, nil)
+	CheckNoErr(t, err, "Blind failed")
+	evaluated, err := Evaluate(&server, blinded, rng)
+	CheckNoErr(t, err, "Evaluate failed")
+	got, err := Unblind(state, evaluated, rng)
+	CheckNoErr(t, err, "Unblind failed")
+	if got.a != want.a {
+		t.Error("Unblind(Evaluate(Blind(x))) differs from the action on x")
+	}
+
+	// The server sees neither the input nor the same curve twice.
+	blinded2, _, err := Blind(&input, rng, &CSIDH512)
+	CheckNoErr(t, err, "Blind failed")
+	if blinded.a == input.a || blinded2.a == input.a || blinded.a == blinded2.a {
+		t.Error("blinded curves must not be linkable to the input")
+	}
+
+	_, err = Unblind(state, evaluated, rng)
+	CheckIsErr(t, err, "Unblind must fail on a used state")
+
+	var invalid PublicKey
+	invalid.a = p
+	_, _, err = Blind(&invalid, rng, nil)
+	CheckIsErr(t, err, "Blind must fail on invalid keys")
+	_, err = Evaluate(&server, &invalid, rng)
+	CheckIsErr(t, err, "Evaluate must fail on invalid keys")
+	_, state, err = Blind(&input, rng, nil)
+	CheckNoErr(t, err, "Blind failed")
+	_, err = Unblind(state, &invalid, rng)
+	CheckIsErr(t, err, "Unblind must fail on invalid keys")
+	params := CSIDH512
+	params.ID = ParamsCSIDH1024
+	_, _, err = Blind(&input, rng, &params)
+	CheckIsErr(t, err, "Blind must fail on unsupported parameters")
+}
+
+func TestPrivateKeyEqual(t *testing.T) {
+	var prv PrivateKey
+	CheckNoErr(t, GeneratePrivateKey(&prv, rng), "PrivateKey generation failed")
+	prv2 := prv
+	CheckOk(prv.Equal(&prv2), "equal keys must compare equal", t)
+	CheckOk(prv.Equal(&prv), "a key must be equal to itself", t)
+
+	// Keys differing in one exponent.
+	e := prv.exponents()
+	for i := range e {
+		f := e
+		f[i] = -f[i]
+		if f[i] == 0 {
+			f[i] = 1
+		}
+		b := prv.Pack()
+		pos := i * expBits
+		b[pos/8] = b[pos/8]&^(0xF<<uint(pos%8)) | byte(f[i]+int16(expMax))<<uint(pos%8)
+		other, err := UnpackPrivateKey(b, &CSIDH512)
+		CheckNoErr(t, err, "UnpackPrivateKey failed")
+		CheckOk(!prv.Equal(other), "keys differing in one exponent must differ", t)
+	}
+
+	var nilKey *PrivateKey
+	CheckOk(!prv.Equal(nil), "a key must not be equal to nil", t)
+	CheckOk(!nilKey.Equal(&prv), "nil must not be equal to a key", t)
+	CheckOk(!nilKey.Equal(nil), "nil keys must not compare equal", t)
+}
+
+func TestDerivePrivateKey(t *testing.T) {
+	var prv1, prv2, prv3 PrivateKey
+	master := []byte("master secret")
+
+	DerivePrivateKey(&prv1, master, "domain A")
+	DerivePrivateKey(&prv2, master, "domain A")
+	DerivePrivateKey(&prv3, master, "domain B")
+
+	if prv1.e != prv2.e {
+		t.Error("Derivation is not deterministic")
+	}
+	if prv1.e == prv3.e {
+		t.Error("Different domains yield the same key")
+	}
+	for _, prv := range []*PrivateKey{&prv1, &prv3} {
+		for i := range primes {
+			e := (prv.e[uint(i)>>1] << ((uint(i) % 2) * 4)) >> 4
+			if e < -expMax || e > expMax {
+				t.Errorf("Exponent %v out of range", e)
+			}
+		}
+	}
+
+	// Derived keys must work for key agreement.
+	var ss1, ss2 [SharedSecretSize]byte
+	var pub1, pub3 PublicKey
+	GeneratePublicKey(&pub1, &prv1, rng)
+	GeneratePublicKey(&pub3, &prv3, rng)
+	CheckOk(DeriveSecret(&ss1, &pub1, &prv3, rng), "Derivation failed", t)
+	CheckOk(DeriveSecret(&ss2, &pub3, &prv1, rng), "Derivation failed", t)
+	if !bytes.Equal(ss1[:], ss2[:]) {
+		t.Error("ss1 != ss2")
+	}
+}
+
 func TestValidateNegative(t *testing.T) {
 	pk := PublicKey{a: p}
 	pk.a[0]++
@@ -124,6 +311,52 @@ func TestValidateNegative(t *testing.T) {
 	}
 }
 
+func TestValidateClass(t *testing.T) {
+	var base PublicKey
+	CheckOk(base.ValidateClass(&CSIDH512, rng), "Base curve has been rejected", t)
+	for i := 0; i < 4; i++ {
+		var prv PrivateKey
+		var pub PublicKey
+		CheckNoErr(t, GeneratePrivateKey(&prv, rng), "PrivateKey generation failed")
+		GeneratePublicKey(&pub, &prv, rng)
+		CheckOk(pub.ValidateClass(&CSIDH512, rng), "Generated key has been rejected", t)
+	}
+
+	// A = a + 1/a gives a curve with full rational 2-torsion, since
+	// A^2 - 4 = (a - 1/a)^2. Its order is a multiple of 8, so it is never
+	// supersingular, and Validate rejects it as well.
+	var a, aInv fp
+	prv := PrivateKey{}
+	for i := 0; i < 4; i++ {
+		prv.randFp(&a, rng)
+		modExpRdc512(&aInv, &a, &pMin1)
+		pk := PublicKey{}
+		addRdc(&pk.a, &a, &aInv)
+		CheckOk(!Validate(&pk, rng), "Curve with full 2-torsion has been validated", t)
+		CheckOk(!pk.ValidateClass(&CSIDH512, rng), "Curve with full 2-torsion has been validated", t)
+	}
+
+	// The supersingular curves with full rational 2-torsion have no
+	// Montgomery model, so they cannot be encoded as public keys. For
+	// instance, the 2-isogeny with kernel (0,0) maps the base curve to
+	// y^2 = x^3 - 4x = x(x-2)(x+2). Moving a root r to 0 gives a Montgomery
+	// model if and only if the product of the other two roots is a square,
+	// and these products are -4 for r = 0 and 8 for r = 2 and r = -2.
+	var four, eight, fourNeg fp
+	addRdc(&four, &two, &two)
+	addRdc(&eight, &four, &four)
+	subRdc(&fourNeg, &fp{}, &four)
+	CheckOk(fourNeg.isNonQuadRes() == 1, "-4 is a square", t)
+	CheckOk(eight.isNonQuadRes() == 1, "8 is a square", t)
+
+	pk := PublicKey{a: twoNeg}
+	CheckOk(!pk.ValidateClass(&CSIDH512, rng), "Public key == -2 has been validated", t)
+	params := CSIDH512
+	params.ID = ParamsCSIDH1024
+	CheckOk(!base.ValidateClass(&params, rng), "Unsupported parameters have been accepted", t)
+	CheckOk(base.ValidateClass(nil, rng), "Nil parameters do not select CSIDH512", t)
+}
+
 func TestPublicKeyExportImport(t *testing.T) {
 	var buf [64]byte
 	eq64 := func(x, y []uint64) bool {
@@ -150,6 +383,326 @@ func TestPublicKeyExportImport(t *testing.T) {
 	}
 }
 
+func TestKeyPEM(t *testing.T) {
+	var prv PrivateKey
+	var pub PublicKey
+	CheckNoErr(t, GeneratePrivateKey(&prv, rng), "PrivateKey generation failed")
+	GeneratePublicKey(&pub, &prv, rng)
+
+	pubPEM := pub.MarshalPEM()
+	if !bytes.HasPrefix(pubPEM, []byte("-----BEGIN CSIDH PUBLIC KEY-----\nParams: cSIDH/512\n")) {
+		t.Fatalf("unexpected armor:\n%s", pubPEM)
+	}
+	pub2, err := ParsePublicKeyPEM(pubPEM)
+	CheckNoErr(t, err, "ParsePublicKeyPEM failed")
+	if pub2.a != pub.a {
+		t.Error("Error occurred when public key PEM encoding/decoding")
+	}
+
+	prvPEM := prv.MarshalPEM()
+	prv2, err := ParsePrivateKeyPEM(prvPEM)
+	CheckNoErr(t, err, "ParsePrivateKeyPEM failed")
+	if prv2.e != prv.e {
+		t.Error("Error occurred when private key PEM encoding/decoding")
+	}
+
+	// Mismatched parameter set, missing header, and unknown name.
+	for _, hdr := range []string{"Params: cSIDH/1024\n", "", "Params: cSIDH/2048\n"} {
+		bad := bytes.Replace(pubPEM, []byte("Params: cSIDH/512\n"), []byte(hdr), 1)
+		_, err = ParsePublicKeyPEM(bad)
+		CheckIsErr(t, err, "ParsePublicKeyPEM must fail on bad params header")
+		bad = bytes.Replace(prvPEM, []byte("Params: cSIDH/512\n"), []byte(hdr), 1)
+		_, err = ParsePrivateKeyPEM(bad)
+		CheckIsErr(t, err, "ParsePrivateKeyPEM must fail on bad params header")
+	}
+	bad := bytes.Replace(pubPEM, []byte("Params: cSIDH/512"), []byte("Params: cSIDH/1024"), 1)
+	if _, err = ParsePublicKeyPEM(bad); !errors.Is(err, errParamsMismatch) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Wrong type, trailing data, and no PEM block.
+	_, err = ParsePublicKeyPEM(prvPEM)
+	CheckIsErr(t, err, "ParsePublicKeyPEM must fail on a private key")
+	_, err = ParsePrivateKeyPEM(pubPEM)
+	CheckIsErr(t, err, "ParsePrivateKeyPEM must fail on a public key")
+	_, err = ParsePublicKeyPEM(append(pubPEM, pubPEM...))
+	CheckIsErr(t, err, "ParsePublicKeyPEM must fail on trailing data")
+	_, err = ParsePublicKeyPEM([]byte("not a PEM block"))
+	CheckIsErr(t, err, "ParsePublicKeyPEM must fail without a PEM block")
+
+	// Non-canonical public key: its coefficient is p.
+	var nonCanonical PublicKey
+	nonCanonical.a = p
+	_, err = ParsePublicKeyPEM(nonCanonical.MarshalPEM())
+	CheckIsErr(t, err, "ParsePublicKeyPEM must fail on non-canonical keys")
+}
+
+func TestPublicKeyParamsID(t *testing.T) {
+	var prv PrivateKey
+	var pub1, pub2 PublicKey
+	CheckNoErr(t, GeneratePrivateKey(&prv, rng), "PrivateKey generation failed")
+	GeneratePublicKey(&pub1, &prv, rng)
+
+	enc := pub1.Bytes()
+	id, err := pub2.SetBytes(enc)
+	CheckNoErr(t, err, "SetBytes failed")
+	if id != ParamsCSIDH512 || pub1.a != pub2.a {
+		t.Error("Error occurred when public key encoding/decoding")
+	}
+
+	params, err := NegotiateParams(ParamsCSIDH512, id)
+	CheckNoErr(t, err, "NegotiateParams failed")
+	if params.ID != ParamsCSIDH512 || params.PublicKeySize != PublicKeySize {
+		t.Error("Wrong negotiated parameters")
+	}
+
+	// A cSIDH/512 key given to a cSIDH/1024 peer.
+	_, err = NegotiateParams(ParamsCSIDH1024, id)
+	CheckIsErr(t, err, "NegotiateParams must fail on mismatch")
+	if !errors.Is(err, errParamsMismatch) || !strings.Contains(err.Error(), "cSIDH/512") {
+		t.Errorf("unclear error: %v", err)
+	}
+	_, err = NegotiateParams(ParamsCSIDH1024, ParamsCSIDH1024)
+	CheckIsErr(t, err, "NegotiateParams must fail on unsupported parameters")
+
+	// A cSIDH/1024 key given to this package.
+	enc1024 := make([]byte, 1+2*PublicKeySize)
+	enc1024[0] = byte(ParamsCSIDH1024)
+	pub2 = pub1
+	id, err = pub2.SetBytes(enc1024)
+	CheckIsErr(t, err, "SetBytes must fail on mismatch")
+	if id != ParamsCSIDH1024 || !errors.Is(err, errParamsMismatch) || pub2.a != pub1.a {
+		t.Errorf("unexpected result: %v %v", id, err)
+	}
+
+	for _, b := range [][]byte{nil, enc[:len(enc)-1], append(enc, 0)} {
+		_, err = pub2.SetBytes(b)
+		CheckIsErr(t, err, "SetBytes must fail on wrong length")
+	}
+}
+
+func TestScheduleExps(t *testing.T) {
+	var buf [2 * primeCount]byte
+	for n := 0; n < 1<<10; n++ {
+		var e, neg [primeCount]int16
+		_, _ = rng.Read(buf[:])
+		for i := range e {
+			e[i] = int16(binary.LittleEndian.Uint16(buf[2*i:])) / 2
+			if n%2 == 0 {
+				e[i] = int16(buf[i]%uint8(2*expMax+1)) - int16(expMax)
+			}
+		}
+		e[0], e[1], e[2] = 0, 1-1<<15, 1<<15-1
+		for i := range e {
+			neg[i] = -e[i]
+		}
+
+		var got, gotNeg actionState
+		got.scheduleExps(&e)
+		gotNeg.scheduleExps(&neg)
+		// Flipping the signs swaps the curve and the twist.
+		if got.e[0] != gotNeg.e[1] || got.e[1] != gotNeg.e[0] ||
+			got.k[0] != gotNeg.k[1] || got.k[1] != gotNeg.k[0] {
+			t.Fatalf("scheduleExps(%v) is not symmetric", e)
+		}
+	}
+
+	// The action of e followed by the action of -e is the identity.
+	var prv PrivateKey
+	var pub PublicKey
+	CheckNoErr(t, GeneratePrivateKey(&prv, rng), "PrivateKey generation failed")
+	e := prv.exponents()
+	var neg [primeCount]int16
+	for i := range e {
+		neg[i] = -e[i]
+	}
+	var s actionState
+	s.scheduleExps(&e)
+	s.run(&pub.a, &prv.fpRngGen, rng)
+	s.scheduleExps(&neg)
+	s.run(&pub.a, &prv.fpRngGen, rng)
+	CheckOk(pub.a.isZero(), "Action of -e does not invert action of e", t)
+}
+
+func TestConfirmSharedSecrets(t *testing.T) {
+	const numPeers = 3
+	var prv PrivateKey
+	var pub PublicKey
+	peers := make([]*PublicKey, numPeers)
+	expected := make([][SharedSecretSize]byte, numPeers)
+
+	CheckNoErr(t, GeneratePrivateKey(&prv, rng), "PrivateKey generation failed")
+	GeneratePublicKey(&pub, &prv, rng)
+	for i := range peers {
+		var peerPrv PrivateKey
+		var peerPub PublicKey
+		CheckNoErr(t, GeneratePrivateKey(&peerPrv, rng), "PrivateKey generation failed")
+		GeneratePublicKey(&peerPub, &peerPrv, rng)
+		peers[i] = &peerPub
+		// DeriveSecret overwrites the public key, so work on a copy.
+		pubCopy := pub
+		CheckOk(DeriveSecret(&expected[i], &pubCopy, &peerPrv, rng), "Derivation failed", t)
+	}
+
+	ok, mismatches := ConfirmSharedSecrets(&prv, peers, expected, rng)
+	CheckOk(ok && len(mismatches) == 0, "Matching secrets were not confirmed", t)
+
+	// Corrupt one expected secret and replace one peer by an invalid key.
+	expected[0][5] ^= 0x01
+	peers[2] = &PublicKey{a: two}
+	ok, mismatches = ConfirmSharedSecrets(&prv, peers, expected, rng)
+	CheckOk(!ok, "Mismatching secrets were confirmed", t)
+	if len(mismatches) != 2 || mismatches[0] != 0 || mismatches[1] != 2 {
+		t.Errorf("got mismatches %v, want [0 2]", mismatches)
+	}
+}
+
+func TestActionBatch(t *testing.T) {
+	const numBases = 3
+	var prv PrivateKey
+	bases := make([]*PublicKey, numBases+1)
+	bases[0] = new(PublicKey)
+	for i := 1; i < len(bases); i++ {
+		var basePrv PrivateKey
+		bases[i] = new(PublicKey)
+		CheckNoErr(t, GeneratePrivateKey(&basePrv, rng), "PrivateKey generation failed")
+		GeneratePublicKey(bases[i], &basePrv, rng)
+	}
+	CheckNoErr(t, GeneratePrivateKey(&prv, rng), "PrivateKey generation failed")
+
+	got := ActionBatch(&prv, bases, rng)
+	if len(got) != len(bases) {
+		t.Fatalf("got %v keys, want %v", len(got), len(bases))
+	}
+	for i, base := range bases {
+		want := *base
+		groupAction(&want, &prv, rng)
+		CheckOk(got[i].a.equal(&want.a), "Batch action differs from single action", t)
+	}
+	CheckOk(len(ActionBatch(&prv, nil, rng)) == 0, "Empty batch must return no keys", t)
+}
+
+// veluAction evaluates the action of e on a with Vélu's formulas only.
+func veluAction(a *fp, e *[primeCount]int16, gen *fpRngGen) {
+	var s actionState
+	s.scheduleExps(e)
+	s.run(a, gen, rng)
+}
+
+func TestRadicalAction(t *testing.T) {
+	var gen fpRngGen
+	for _, e0 := range []int16{-5, -1, 0, 2, 5} {
+		for _, e1 := range []int16{-4, 0, 1, 5} {
+			var e [primeCount]int16
+			e[0], e[1] = e0, e1
+			var got, want fp
+			veluAction(&want, &e, &gen)
+			radicalAction(&got, &e, &gen, rng, nil)
+			CheckOk(e == [primeCount]int16{}, "Radical exponents were not cleared", t)
+			if !got.equal(&want) {
+				t.Fatalf("radical chain for exponents (%v, %v) differs from Vélu", e0, e1)
+			}
+		}
+	}
+
+	for _, e0 := range []int16{-int16(expMax) - 1, int16(expMax) + 1} {
+		var e [primeCount]int16
+		e[1] = e0
+		var a fp
+		err := CheckPanic(func() { radicalAction(&a, &e, &gen, rng, nil) })
+		CheckNoErr(t, err, "out of range exponent accepted")
+	}
+
+	for i := 0; i < 2; i++ {
+		var prv PrivateKey
+		var got PublicKey
+		var want fp
+		CheckNoErr(t, GeneratePrivateKey(&prv, rng), "PrivateKey generation failed")
+		e := prv.exponents()
+		veluAction(&want, &e, &prv.fpRngGen)
+		groupAction(&got, &prv, rng)
+		CheckOk(got.a.equal(&want), "Group action differs from Vélu", t)
+	}
+
+	// Imported keys may have exponents beyond expMax, whose excess is
+	// evaluated with Vélu's formulas.
+	var prv PrivateKey
+	var got PublicKey
+	var want fp
+	e := [primeCount]int16{-8, 7}
+	prv.setExponents(&e)
+	veluAction(&want, &e, &prv.fpRngGen)
+	groupAction(&got, &prv, rng)
+	CheckOk(got.a.equal(&want), "Group action differs from Vélu beyond expMax", t)
+}
+
+func TestActionWithBase(t *testing.T) {
+	var basePrv PrivateKey
+	var pub PublicKey
+	CheckNoErr(t, GeneratePrivateKey(&basePrv, rng), "PrivateKey generation failed")
+	GeneratePublicKey(&pub, &basePrv, rng)
+	base, err := PrepareBase(&pub, &CSIDH512, rng)
+	CheckNoErr(t, err, "PrepareBase failed")
+
+	for i := 0; i < 3; i++ {
+		var prv PrivateKey
+		var got, want [SharedSecretSize]byte
+		CheckNoErr(t, GeneratePrivateKey(&prv, rng), "PrivateKey generation failed")
+		ActionWithBase(&got, &prv, base, rng)
+		pubCopy := pub
+		CheckOk(DeriveSecret(&want, &pubCopy, &prv, rng), "DeriveSecret failed", t)
+		if got != want {
+			t.Errorf("ActionWithBase differs from DeriveSecret")
+		}
+	}
+
+	_, err = PrepareBase(&PublicKey{a: two}, nil, rng)
+	CheckIsErr(t, err, "PrepareBase must fail on invalid keys")
+	_, err = PrepareBase(nil, nil, rng)
+	CheckIsErr(t, err, "PrepareBase must fail on nil keys")
+	params := CSIDH512
+	params.ID = ParamsCSIDH1024
+	_, err = PrepareBase(&pub, &params, rng)
+	CheckIsErr(t, err, "PrepareBase must fail on unsupported parameters")
+}
+
+func TestActionDummyFree(t *testing.T) {
+	var basePrv PrivateKey
+	var base PublicKey
+	CheckNoErr(t, GeneratePrivateKey(&basePrv, rng), "PrivateKey generation failed")
+	GeneratePublicKey(&base, &basePrv, rng)
+
+	for i := 0; i < 3; i++ {
+		var prv DummyFreePrivateKey
+		CheckNoErr(t, GenerateDummyFreePrivateKey(&prv, rng), "PrivateKey generation failed")
+		var e [primeCount]int16
+		for j, v := range prv.e {
+			if v%2 != 0 || v > dummyFreeMax || v < -dummyFreeMax {
+				t.Fatalf("bad exponent %v", v)
+			}
+			e[j] = int16(v)
+		}
+
+		for _, b := range []*PublicKey{{}, &base} {
+			got, err := ActionDummyFree(&prv, b, nil, rng)
+			CheckNoErr(t, err, "ActionDummyFree failed")
+			var s actionState
+			want := b.a
+			s.scheduleExps(&e)
+			s.run(&want, &prv.fpRngGen, rng)
+			if !got.a.equal(&want) {
+				t.Errorf("ActionDummyFree differs from the group action")
+			}
+		}
+	}
+
+	var prv DummyFreePrivateKey
+	params := CSIDH512
+	params.ID = ParamsCSIDH1024
+	_, err := ActionDummyFree(&prv, &base, &params, rng)
+	CheckIsErr(t, err, "ActionDummyFree must fail on unsupported parameters")
+}
+
 // Test vectors generated by reference implementation.
 func TestKAT(t *testing.T) {
 	var tests TestVectors
@@ -330,6 +883,79 @@ func BenchmarkValidateGenerated(b *testing.B) {
 	}
 }
 
+// Benchmark the group action on the base curve.
+func BenchmarkAction(b *testing.B) {
+	_ = GeneratePrivateKey(&prv1, rng)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		var pub PublicKey
+		groupAction(&pub, &prv1, rng)
+	}
+}
+
+// Benchmark the group action on the base curve with and without radical
+// isogenies for the smallest primes.
+func BenchmarkRadicalAction(b *testing.B) {
+	_ = GeneratePrivateKey(&prv1, rng)
+	b.Run("Velu", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			var a fp
+			e := prv1.exponents()
+			veluAction(&a, &e, &prv1.fpRngGen)
+		}
+	})
+	b.Run("Radical", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			var pub PublicKey
+			groupAction(&pub, &prv1, rng)
+		}
+	})
+}
+
+// Benchmark the group action on the base curve, and report the time
+// spent on the isogenies of each prime degree.
+func BenchmarkActionByPrime(b *testing.B) {
+	var perPrime [primeCount]time.Duration
+	_ = GeneratePrivateKey(&prv1, rng)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		var pub PublicKey
+		groupActionTimed(&pub, &prv1, rng, &perPrime)
+	}
+	b.StopTimer()
+	for i, v := range primes {
+		b.ReportMetric(float64(perPrime[i].Nanoseconds())/float64(b.N), fmt.Sprintf("ns/l%d", v))
+	}
+}
+
+// Benchmark the group action on a batch of curves, compared with
+// applying the action on each curve separately.
+func BenchmarkActionBatch(b *testing.B) {
+	const batch = 4
+	bases := make([]*PublicKey, batch)
+	for i := range bases {
+		var prv PrivateKey
+		bases[i] = new(PublicKey)
+		_ = GeneratePrivateKey(&prv, rng)
+		GeneratePublicKey(bases[i], &prv, rng)
+	}
+	_ = GeneratePrivateKey(&prv1, rng)
+
+	b.Run("Single", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for _, base := range bases {
+				pub := *base
+				groupAction(&pub, &prv1, rng)
+			}
+		}
+	})
+	b.Run("Batch", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			ActionBatch(&prv1, bases, rng)
+		}
+	})
+}
+
 // Generate some keys and benchmark derive.
 func BenchmarkDerive(b *testing.B) {
 	var ss [64]byte
@@ -360,3 +986,44 @@ func BenchmarkDeriveGenerated(b *testing.B) {
 		DeriveSecret(&ss, &pub2, &prv1, rng)
 	}
 }
+
+// Benchmark the dummy-free group action, compared with the group action of
+// a key from GeneratePrivateKey.
+func BenchmarkActionDummyFree(b *testing.B) {
+	var prv PrivateKey
+	var dfPrv DummyFreePrivateKey
+	_ = GeneratePrivateKey(&prv, rng)
+	_ = GenerateDummyFreePrivateKey(&dfPrv, rng)
+	b.Run("Action", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			var pub PublicKey
+			groupAction(&pub, &prv, rng)
+		}
+	})
+	b.Run("DummyFree", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			_, _ = ActionDummyFree(&dfPrv, &PublicKey{}, nil, rng)
+		}
+	})
+}
+
+func BenchmarkActionWithBase(b *testing.B) {
+	var prv PrivateKey
+	var pub PublicKey
+	var ss [SharedSecretSize]byte
+	_ = GeneratePrivateKey(&prv, rng)
+	GeneratePublicKey(&pub, &prv, rng)
+	base, _ := PrepareBase(&pub, nil, rng)
+
+	b.Run("DeriveSecret", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			pubCopy := pub
+			DeriveSecret(&ss, &pubCopy, &prv, rng)
+		}
+	})
+	b.Run("ActionWithBase", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			ActionWithBase(&ss, &prv, base, rng)
+		}
+	})
+}
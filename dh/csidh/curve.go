@@ -1,5 +1,7 @@
 package csidh
 
+import "math/bits"
+
 // xAdd implements differential arithmetic in P^1 for Montgomery
 // curves E(x): x^3 + A*x^2 + x by using x-coordinate only arithmetic.
 //
@@ -123,6 +125,58 @@ func xMul(kP, P *point, co *coeff, k *fp) {
 	*kP = Q
 }
 
+// ladderMul implements point multiplication with the Montgomery ladder,
+// where k < 2^nbits. Unlike xMul, it performs exactly nbits steps of xDblAdd
+// regardless of the value of k, and so its running time does not depend on
+// the bit length of k, as long as nbits is a public bound. It returns the
+// point at infinity (1:0) if k=0.
+func ladderMul(kP, P *point, co *coeff, k *fp, nbits uint) { _ = ladder(kP, P, co, k, nbits) }
+
+// ladder is ladderMul, and returns the number of steps performed.
+func ladder(kP, P *point, co *coeff, k *fp, nbits uint) (steps uint) {
+	var A24 coeff
+	addRdc(&A24.a, &co.c, &co.c)
+	addRdc(&A24.a, &A24.a, &co.a)
+	mulRdc(&A24.c, &co.c, &four)
+
+	// Invariant: R1-R0 = P.
+	R0 := point{x: one}
+	R1 := *P
+	swap := uint8(0)
+	for i := nbits; i > 0; {
+		i--
+		bit := uint8(k[i>>6] >> (i & 63) & 1)
+		cswappoint(&R0, &R1, swap^bit)
+		xDblAdd(&R0, &R1, &R0, &R1, P, &A24)
+		swap = bit
+		steps++
+	}
+	cswappoint(&R0, &R1, swap)
+	*kP = R0
+	return steps
+}
+
+// cofactorBits[i] is the bit length of the product of primes[i+1:], which
+// bounds the cofactors used by the group action to find a kernel point.
+var cofactorBits = func() (b [primeCount]uint) {
+	cof := fp{1}
+	for i := primeCount - 1; i >= 0; i-- {
+		b[i] = bitLen(&cof)
+		mul512(&cof, &cof, primes[i])
+	}
+	return
+}()
+
+// bitLen returns the bit length of x.
+func bitLen(x *fp) uint {
+	for i := numWords - 1; i >= 0; i-- {
+		if x[i] != 0 {
+			return uint(i*limbBitSize + bits.Len64(x[i]))
+		}
+	}
+	return 0
+}
+
 // xIso computes the isogeny with kernel point kern of a given order
 // kernOrder. Returns the new curve coefficient co and the image img.
 //
@@ -132,10 +186,20 @@ func xMul(kP, P *point, co *coeff, k *fp) {
 //
 // Non-constant time.
 func xIso(img *point, co *coeff, kern *point, kernOrder uint64) {
-	var t0, t1, t2, S, D fp
-	var Q, prod point
+	imgs := [...]*point{img}
+	xIsoPoints(imgs[:], co, kern, kernOrder)
+}
+
+// maxIsoPoints is the maximum number of points mapped by xIsoPoints.
+const maxIsoPoints = 2
+
+// xIsoPoints is as xIso, but it maps each of the points in imgs, which
+// share the computation of the multiples of kern.
+func xIsoPoints(imgs []*point, co *coeff, kern *point, kernOrder uint64) {
+	var Qs [maxIsoPoints]point
+	var prod point
 	var coEd coeff
-	M := [3]point{*kern}
+	Q := Qs[:len(imgs)]
 
 	// Compute twisted Edwards coefficients
 	// coEd.a = co.a + 2*co.c
@@ -145,43 +209,15 @@ func xIso(img *point, co *coeff, kern *point, kernOrder uint64) {
 	addRdc(&coEd.a, &co.a, &coEd.c)
 	subRdc(&coEd.c, &co.a, &coEd.c)
 
-	// Transfer point to twisted Edwards YZ-coordinates
-	// (X:Z)->(Y:Z) = (X-Z : X+Z)
-	addRdc(&S, &img.x, &img.z)
-	subRdc(&D, &img.x, &img.z)
-
-	subRdc(&prod.x, &kern.x, &kern.z)
-	addRdc(&prod.z, &kern.x, &kern.z)
-
-	mulRdc(&t1, &prod.x, &S)
-	mulRdc(&t0, &prod.z, &D)
-	addRdc(&Q.x, &t0, &t1)
-	subRdc(&Q.z, &t0, &t1)
-
-	xDbl(&M[1], kern, &point{x: co.a, z: co.c})
+	veluProducts(&prod, Q, imgs, co, kern, kernOrder)
 
-	// NOTE: Not constant time.
-	for i := uint64(1); i < kernOrder>>1; i++ {
-		if i >= 2 {
-			xAdd(&M[i%3], &M[(i-1)%3], kern, &M[(i-2)%3])
-		}
-		subRdc(&t1, &M[i%3].x, &M[i%3].z)
-		addRdc(&t0, &M[i%3].x, &M[i%3].z)
-		mulRdc(&prod.x, &prod.x, &t1)
-		mulRdc(&prod.z, &prod.z, &t0)
-		mulRdc(&t1, &t1, &S)
-		mulRdc(&t0, &t0, &D)
-		addRdc(&t2, &t0, &t1)
-		mulRdc(&Q.x, &Q.x, &t2)
-		subRdc(&t2, &t0, &t1)
-		mulRdc(&Q.z, &Q.z, &t2)
+	for i, img := range imgs {
+		mulRdc(&Q[i].x, &Q[i].x, &Q[i].x)
+		mulRdc(&Q[i].z, &Q[i].z, &Q[i].z)
+		mulRdc(&img.x, &img.x, &Q[i].x)
+		mulRdc(&img.z, &img.z, &Q[i].z)
 	}
 
-	mulRdc(&Q.x, &Q.x, &Q.x)
-	mulRdc(&Q.z, &Q.z, &Q.z)
-	mulRdc(&img.x, &img.x, &Q.x)
-	mulRdc(&img.z, &img.z, &Q.z)
-
 	// coEd.a^kernOrder and coEd.c^kernOrder
 	modExpRdc64(&coEd.a, &coEd.a, kernOrder)
 	modExpRdc64(&coEd.c, &coEd.c, kernOrder)
@@ -204,6 +240,55 @@ func xIso(img *point, co *coeff, kern *point, kernOrder uint64) {
 	addRdc(&co.a, &co.a, &co.a)
 }
 
+// veluProducts computes, up to a common factor, the products over the
+// multiples [s]kern for s in [1, (kernOrder-1)/2]
+//
+//	prod = (\prod (X_s - Z_s) : \prod (X_s + Z_s)),
+//	Q[j] = (\prod (X_s*x - Z_s*z) : \prod (Z_s*x - X_s*z)),
+//
+// where (x:z) = imgs[j], by computing each multiple.
+func veluProducts(prod *point, Q []point, imgs []*point, co *coeff, kern *point, kernOrder uint64) {
+	var t0, t1, t2, u0, u1 fp
+	var S, D [maxIsoPoints]fp
+	M := [3]point{*kern}
+
+	subRdc(&prod.x, &kern.x, &kern.z)
+	addRdc(&prod.z, &kern.x, &kern.z)
+
+	for j, img := range imgs {
+		// Transfer point to twisted Edwards YZ-coordinates
+		// (X:Z)->(Y:Z) = (X-Z : X+Z)
+		addRdc(&S[j], &img.x, &img.z)
+		subRdc(&D[j], &img.x, &img.z)
+
+		mulRdc(&t1, &prod.x, &S[j])
+		mulRdc(&t0, &prod.z, &D[j])
+		addRdc(&Q[j].x, &t0, &t1)
+		subRdc(&Q[j].z, &t0, &t1)
+	}
+
+	xDbl(&M[1], kern, &point{x: co.a, z: co.c})
+
+	// NOTE: Not constant time.
+	for i := uint64(1); i < kernOrder>>1; i++ {
+		if i >= 2 {
+			xAdd(&M[i%3], &M[(i-1)%3], kern, &M[(i-2)%3])
+		}
+		subRdc(&u1, &M[i%3].x, &M[i%3].z)
+		addRdc(&u0, &M[i%3].x, &M[i%3].z)
+		mulRdc(&prod.x, &prod.x, &u1)
+		mulRdc(&prod.z, &prod.z, &u0)
+		for j := range imgs {
+			mulRdc(&t1, &u1, &S[j])
+			mulRdc(&t0, &u0, &D[j])
+			addRdc(&t2, &t0, &t1)
+			mulRdc(&Q[j].x, &Q[j].x, &t2)
+			subRdc(&t2, &t0, &t1)
+			mulRdc(&Q[j].z, &Q[j].z, &t2)
+		}
+	}
+}
+
 // montEval evaluates x^3 + Ax^2 + x.
 func montEval(res, A, x *fp) {
 	var t fp
@@ -1,6 +1,7 @@
 package csidh
 
 import (
+	"fmt"
 	"math/big"
 	"testing"
 )
@@ -312,6 +313,119 @@ func TestMappointHardcoded5(t *testing.T) {
 	}
 }
 
+// affineMul returns the x-coordinate of [k]P on the curve y^2 = x^3 + Ax^2 + x,
+// where P = (x, y), using affine double-and-add. It returns nil for the point
+// at infinity.
+func affineMul(A, x, y, k *big.Int) *big.Int {
+	var qx, qy *big.Int
+	add := func(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
+		if x1 == nil {
+			return x2, y2
+		}
+		l, d := new(big.Int), new(big.Int)
+		if x1.Cmp(x2) == 0 {
+			if new(big.Int).Add(y1, y2).Cmp(modulus) == 0 || y1.Sign() == 0 {
+				return nil, nil
+			}
+			// l = (3x^2 + 2Ax + 1) / 2y
+			l.Mul(x1, x1).Mul(l, big.NewInt(3))
+			d.Mul(A, x1).Lsh(d, 1)
+			l.Add(l, d).Add(l, big.NewInt(1))
+			d.Lsh(y1, 1)
+		} else {
+			// l = (y2 - y1) / (x2 - x1)
+			l.Sub(y2, y1)
+			d.Sub(x2, x1)
+		}
+		d.ModInverse(d.Mod(d, modulus), modulus)
+		l.Mul(l, d).Mod(l, modulus)
+		x3 := new(big.Int).Mul(l, l)
+		x3.Sub(x3, A).Sub(x3, x1).Sub(x3, x2).Mod(x3, modulus)
+		y3 := new(big.Int).Sub(x1, x3)
+		y3.Mul(y3, l).Sub(y3, y1).Mod(y3, modulus)
+		return x3, y3
+	}
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		qx, qy = add(qx, qy, qx, qy)
+		if k.Bit(i) == 1 {
+			qx, qy = add(qx, qy, x, y)
+		}
+	}
+	return qx
+}
+
+func TestLadderMul(t *testing.T) {
+	const A = "0x538F785D52996919C8D5C73D842A0249669B5B6BB05338B74EAE8094AE5009A3BA2D73730F527D7403E8184D9B1FA11C0C4C40E7B328A84874A6DBCE99E1DF92"
+	var co coeff
+	var P, kP, want point
+	bigA, _ := new(big.Int).SetString(A, 0)
+	co.a = toFp(A)
+	co.c = toFp("1")
+
+	// Find a point P = (x, y) on the curve.
+	x, y := big.NewInt(1), new(big.Int)
+	for {
+		x.Add(x, big.NewInt(1))
+		rhs := new(big.Int).Mul(x, x)
+		rhs.Add(rhs, new(big.Int).Mul(bigA, x)).Add(rhs, big.NewInt(1))
+		rhs.Mul(rhs, x).Mod(rhs, modulus)
+		if y.ModSqrt(rhs, modulus) != nil {
+			break
+		}
+	}
+	P.x = toFp("0x" + x.Text(16))
+	P.z = toFp("1")
+
+	for _, nbits := range []uint{1, 7, 64, 100, 511, 512} {
+		scalars := []fp{{}, {1}}
+		for i := 0; i < numIter; i++ {
+			k := randomFp()
+			// Clear the bits above nbits, and some random top bits.
+			for j := uint(0); j < 512; j++ {
+				if j >= nbits || (j >= nbits/2 && i%2 == 0) {
+					k[j>>6] &^= 1 << (j & 63)
+				}
+			}
+			scalars = append(scalars, k)
+		}
+
+		for _, k := range scalars {
+			var bigK big.Int
+			intSetU64(&bigK, k[:])
+			// The number of steps depends only on nbits.
+			if n := ladder(&kP, &P, &co, &k, nbits); n != nbits {
+				t.Errorf("nbits=%v k=%v: expected %v ladder steps, got %v", nbits, &bigK, nbits, n)
+			}
+
+			wantX := affineMul(bigA, x, y, &bigK)
+			if wantX == nil {
+				if !kP.z.isZero() {
+					t.Errorf("nbits=%v k=%v: expected the point at infinity", nbits, &bigK)
+				}
+				continue
+			}
+			if got := toNormX(&kP); got.Cmp(wantX) != 0 {
+				t.Errorf("nbits=%v k=%v:\nExp: %s\nGot: %s", nbits, &bigK, wantX.Text(16), got.Text(16))
+			}
+
+			xMul(&want, &P, &co, &k)
+			gotX, wantXMul := toNormX(&kP), toNormX(&want)
+			if gotX.Cmp(&wantXMul) != 0 {
+				t.Errorf("nbits=%v k=%v: ladderMul and xMul differ", nbits, &bigK)
+			}
+		}
+	}
+
+	// Check if first and second argument can overlap
+	k := fp{0x7A36C930A83EFBD5, 0xD0E80041ED0DDF9F}
+	xMul(&want, &P, &co, &k)
+	ladderMul(&P, &P, &co, &k, 128)
+	gotX, wantX := toNormX(&P), toNormX(&want)
+	if gotX.Cmp(&wantX) != 0 {
+		t.Errorf("\nExp: %s\nGot: %s", wantX.Text(16), gotX.Text(16))
+	}
+}
+
 func BenchmarkXMul(b *testing.B) {
 	var kP, P point
 	var co coeff
@@ -331,6 +445,22 @@ func BenchmarkXMul(b *testing.B) {
 	}
 }
 
+func BenchmarkLadderMul(b *testing.B) {
+	var kP, P point
+	var co coeff
+	var k fp
+
+	P.x = toFp("0x1C5CA539C1D5B52DE4750C390C24C05251E8B1D33E48971FA86F5ADDED2D06C8CD31E94887541468BB2925EBD693C9DDFF5BD9508430F25FE28EE30C0760C0FE")
+	P.z = toFp("1")
+	co.a = toFp("0x538F785D52996919C8D5C73D842A0249669B5B6BB05338B74EAE8094AE5009A3BA2D73730F527D7403E8184D9B1FA11C0C4C40E7B328A84874A6DBCE99E1DF92")
+	co.c = toFp("1")
+	k = fp{0x7A36C930A83EFBD5, 0xD0E80041ED0DDF9F, 0x5AA17134F1B8F877, 0x975711EC94168E51, 0xB3CAD962BED4BAC5, 0x3026DFDD7E4F5687, 0xE67F91AB8EC9C3AF, 0x34671D3FD8C317E7}
+
+	for n := 0; n < b.N; n++ {
+		ladderMul(&kP, &P, &co, &k, pbits)
+	}
+}
+
 func BenchmarkXAdd(b *testing.B) {
 	var P, Q, PdQ point
 	var PaQ point
@@ -379,3 +509,49 @@ func BenchmarkIsom(b *testing.B) {
 		xIso(&P, &co, &kern, k)
 	}
 }
+
+// randomReducedFp returns a random element of GF(p) in [0, p).
+func randomReducedFp() (u fp) {
+	u = randomFp()
+	v, _ := new(big.Int).SetString(fp2S(u), 16)
+	v.Mod(v, modulus)
+	copy(u[:], intGetU64(v))
+	return
+}
+
+// kernelPoint returns a point of order primes[idx] on the curve co.
+func kernelPoint(co *coeff, idx int) point {
+	k := fp{4}
+	for j, v := range primes {
+		if j != idx {
+			mul512(&k, &k, v)
+		}
+	}
+	for {
+		var P point
+		var rhs fp
+		P.x, P.z = randomReducedFp(), one
+		montEval(&rhs, &co.a, &P.x)
+		if rhs.isNonQuadRes() == 0 {
+			xMul(&P, &P, co, &k)
+			if !P.z.isZero() {
+				return P
+			}
+		}
+	}
+}
+
+func BenchmarkXIso(b *testing.B) {
+	co := coeff{a: fp{}, c: one}
+	for _, idx := range []int{len(primes) - 20, len(primes) - 2, len(primes) - 1} {
+		l := primes[idx]
+		kern := kernelPoint(&co, idx)
+		img := point{x: randomReducedFp(), z: one}
+		b.Run(fmt.Sprintf("%v", l), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				P, c := img, co
+				xIso(&P, &c, &kern, l)
+			}
+		})
+	}
+}
@@ -7,4 +7,5 @@
 // References:
 //   - cSIDH:        ia.cr/2018/383
 //   - Faster cSIDH: ia.cr/2018/782
+//   - SeaSign:      ia.cr/2018/824
 package csidh
@@ -0,0 +1,129 @@
+package csidh
+
+import "io"
+
+// dummyFreeMax is the bound m on the absolute value of the exponents of a
+// DummyFreePrivateKey. The exponents have the same parity as m, so there
+// are m+1 of them per prime, and m = 10 gives as many keys as the exponents
+// in [-5, 5] of PrivateKey.
+const dummyFreeMax = 10
+
+// DummyFreePrivateKey is a private key for ActionDummyFree. Its exponents
+// are even integers in [-10, 10], which do not fit in the encoding of
+// PrivateKey.
+type DummyFreePrivateKey struct {
+	fpRngGen
+	e [primeCount]int8
+}
+
+// GenerateDummyFreePrivateKey generates a private key for ActionDummyFree,
+// whose exponents are sampled uniformly from {-10, -8, ..., 8, 10}. Thus,
+// there are 11^74 (about 2^256) such keys, as for GeneratePrivateKey.
+func GenerateDummyFreePrivateKey(key *DummyFreePrivateKey, rng io.Reader) error {
+	for i := 0; i < len(primes); {
+		_, err := io.ReadFull(rng, key.wbuf[:])
+		if err != nil {
+			return err
+		}
+
+		for j := range key.wbuf {
+			if v := key.wbuf[j] & 0xF; v <= dummyFreeMax {
+				key.e[i] = 2*int8(v) - dummyFreeMax
+				i = i + 1
+				if i == len(primes) {
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ActionDummyFree evaluates the group action of prv on base, and returns the
+// resulting public key. It follows the dummy-free method of
+// Cervantes-Vázquez et al. (ia.cr/2018/1198): every prime takes exactly 10
+// isogeny steps, all of them real, and its exponent only decides how many
+// are taken towards the curve or its twist. The parameter set params must
+// be CSIDH512; nil selects it. The keys are not validated.
+//
+// In each round, a point on the curve and a point on its twist are mapped
+// through every isogeny, and the kernel is taken from one of them by a
+// constant-time swap. Unlike an action with dummy isogenies, a fault in any
+// step corrupts the output, so faults do not reveal which steps were real.
+// The price is speed: every prime takes twice as many steps as the largest
+// exponent of GeneratePrivateKey, and both points are mapped, which makes
+// it about four times as slow as GeneratePublicKey.
+func ActionDummyFree(prv *DummyFreePrivateKey, base *PublicKey, params *ParamSet, rng io.Reader) (*PublicKey, error) {
+	if params != nil && params.ID != ParamsCSIDH512 {
+		return nil, errParamsUnsupported(params.ID)
+	}
+
+	// The number of steps left for each prime is public; of them, pos are
+	// taken towards the curve, and the rest towards its twist.
+	var steps, pos [primeCount]uint16
+	for i, e := range prv.e {
+		steps[i] = dummyFreeMax
+		pos[i] = uint16((dummyFreeMax + int16(e)) / 2)
+	}
+
+	A := coeff{a: base.a, c: one}
+	for pending := true; pending; {
+		// P[0] is on the curve, and P[1] on its twist.
+		var P [2]point
+		for sign := range P {
+			for {
+				var rhs fp
+				prv.randFp(&P[sign].x, rng)
+				P[sign].z = one
+				montEval(&rhs, &A.a, &P[sign].x)
+				if rhs.isNonQuadRes() == sign {
+					break
+				}
+			}
+		}
+		k := fp{4}
+		for i, v := range primes {
+			if steps[i] == 0 {
+				mul512(&k, &k, v)
+			}
+		}
+		xMul(&P[0], &P[0], &A, &k)
+		xMul(&P[1], &P[1], &A, &k)
+
+		for i, v := range primes {
+			if steps[i] == 0 {
+				continue
+			}
+			cof := fp{1}
+			for j := i + 1; j < len(primes); j++ {
+				if steps[j] != 0 {
+					mul512(&cof, &cof, primes[j])
+				}
+			}
+
+			// Swap in the point on the twist once pos is exhausted.
+			twist := uint8((uint32(pos[i]) - 1) >> 31)
+			cswappoint(&P[0], &P[1], twist)
+			var K point
+			xMul(&K, &P[0], &A, &cof)
+			if !K.z.isZero() {
+				imgs := [...]*point{&P[0], &P[1]}
+				xIsoPoints(imgs[:], &A, &K, v)
+				steps[i]--
+				pos[i] -= uint16(1 - twist)
+			}
+			cswappoint(&P[0], &P[1], twist)
+			xMul(&P[0], &P[0], &A, &fp{v})
+			xMul(&P[1], &P[1], &A, &fp{v})
+		}
+
+		modExpRdc512(&A.c, &A.c, &pMin1)
+		mulRdc(&A.a, &A.a, &A.c)
+		A.c = one
+		pending = false
+		for i := range steps {
+			pending = pending || steps[i] != 0
+		}
+	}
+	return &PublicKey{a: A.a}, nil
+}
@@ -0,0 +1,85 @@
+package csidh
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// expBits is the number of bits of a packed exponent, ceil(log2(2*m+1)),
+// where m = expMax bounds the absolute value of the exponents. All the
+// primes of cSIDH/512 share this bound.
+const expBits = 4
+
+// PackedPrivateKeySize is the size in bytes of private keys packed by
+// PrivateKey.Pack.
+const PackedPrivateKeySize = (primeCount*expBits + 7) / 8
+
+var (
+	errPackedLength   = errors.New("csidh: bad packed private key length")
+	errPackedPadding  = errors.New("csidh: non-zero padding in packed private key")
+	errPackedExponent = errors.New("csidh: packed exponent out of range")
+)
+
+// Pack returns the exponents of the private key bit-packed in little-endian
+// order, each one as e+m using expBits bits, where m is its bound. Unlike
+// Export, which writes the exponents in two's complement, the encoding of
+// every exponent is non-negative, so that UnpackPrivateKey can check its
+// range.
+func (c *PrivateKey) Pack() []byte {
+	e := c.exponents()
+	out := make([]byte, PackedPrivateKeySize)
+	var acc, n uint
+	j := 0
+	for _, v := range e {
+		acc |= uint(v+int16(expMax)) << n
+		for n += expBits; n >= 8; n -= 8 {
+			out[j] = byte(acc)
+			acc >>= 8
+			j++
+		}
+	}
+	if n > 0 {
+		out[j] = byte(acc)
+	}
+	return out
+}
+
+// UnpackPrivateKey returns the private key encoded in b by Pack. The
+// parameter set params must be CSIDH512; nil selects it. It returns an error
+// if params is not supported, if b does not have PackedPrivateKeySize bytes,
+// or if any exponent is out of the range [-m, m]. The time it takes does not
+// depend on the exponents.
+func UnpackPrivateKey(b []byte, params *ParamSet) (*PrivateKey, error) {
+	if params != nil && params.ID != ParamsCSIDH512 {
+		return nil, errParamsUnsupported(params.ID)
+	}
+	if len(b) != PackedPrivateKeySize {
+		return nil, errPackedLength
+	}
+	key := new(PrivateKey)
+	var acc, n, bad uint
+	j := 0
+	for i := 0; i < primeCount; i++ {
+		for ; n < expBits; n += 8 {
+			acc |= uint(b[j]) << n
+			j++
+		}
+		v := acc & (1<<expBits - 1)
+		acc >>= expBits
+		n -= expBits
+		// The exponents are only checked once all of them are decoded, so
+		// that an invalid key is not rejected in secret-dependent time.
+		bad |= (uint(2*expMax) - v) >> (bits.UintSize - 1)
+		// Inverse of PrivateKey.exponents.
+		t := int8(v) - expMax
+		key.e[i>>1] |= int8((uint8(t) & 0xF) << uint((1-i%2)*4))
+	}
+	if bad != 0 {
+		return nil, errPackedExponent
+	}
+	// Unused bits must be zero, so that encodings are unique.
+	if acc != 0 {
+		return nil, errPackedPadding
+	}
+	return key, nil
+}
@@ -0,0 +1,112 @@
+package csidh
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ParamsID identifies a CSIDH parameter set in the encoding of public keys,
+// so that peers configured with different parameter sets detect the mismatch
+// instead of deriving unrelated shared secrets.
+type ParamsID uint8
+
+const (
+	// ParamsCSIDH512 identifies cSIDH/512, the parameter set implemented by
+	// this package.
+	ParamsCSIDH512 ParamsID = 0x01
+	// ParamsCSIDH1024 identifies cSIDH/1024. It is recognized in encodings,
+	// but is not supported by this package.
+	ParamsCSIDH1024 ParamsID = 0x02
+)
+
+// String returns the name of the parameter set.
+func (id ParamsID) String() string {
+	switch id {
+	case ParamsCSIDH512:
+		return "cSIDH/512"
+	case ParamsCSIDH1024:
+		return "cSIDH/1024"
+	default:
+		return fmt.Sprintf("ParamsID(%#02x)", uint8(id))
+	}
+}
+
+// ParamSet describes a CSIDH parameter set supported by this package.
+type ParamSet struct {
+	ID ParamsID
+	// PrivateKeySize is the size in bytes of private keys.
+	PrivateKeySize int
+	// PublicKeySize is the size in bytes of public keys, as exported by
+	// PublicKey.Export, that is, without the ParamsID.
+	PublicKeySize int
+	// SharedSecretSize is the size in bytes of shared secrets.
+	SharedSecretSize int
+}
+
+// CSIDH512 is the cSIDH/512 parameter set.
+var CSIDH512 = ParamSet{
+	ID:               ParamsCSIDH512,
+	PrivateKeySize:   PrivateKeySize,
+	PublicKeySize:    PublicKeySize,
+	SharedSecretSize: SharedSecretSize,
+}
+
+// TaggedPublicKeySize is the size in bytes of public keys encoded by
+// PublicKey.Bytes.
+const TaggedPublicKeySize = 1 + PublicKeySize
+
+var (
+	errParamsMismatch = errors.New("csidh: parameter sets do not match")
+	errParamsLength   = errors.New("csidh: bad tagged public key length")
+)
+
+// errParamsUnsupported is returned for a parameter set that this package
+// does not implement.
+func errParamsUnsupported(id ParamsID) error {
+	return fmt.Errorf("csidh: unsupported parameter set %v", id)
+}
+
+// NegotiateParams returns the parameter set agreed by a local and a remote
+// peer, which is only possible if both use the same one, and it is supported
+// by this package. Otherwise, it returns an error.
+func NegotiateParams(localID, remoteID ParamsID) (*ParamSet, error) {
+	if localID != remoteID {
+		return nil, fmt.Errorf("%w: local %v, remote %v", errParamsMismatch, localID, remoteID)
+	}
+	if localID != ParamsCSIDH512 {
+		return nil, errParamsUnsupported(localID)
+	}
+	params := CSIDH512
+	return &params, nil
+}
+
+// Bytes returns the encoding of the public key prefixed with its ParamsID,
+// which is TaggedPublicKeySize bytes long.
+func (c *PublicKey) Bytes() []byte {
+	out := make([]byte, TaggedPublicKeySize)
+	out[0] = byte(ParamsCSIDH512)
+	c.Export(out[1:])
+	return out
+}
+
+// SetBytes sets c to the public key encoded by b, as returned by Bytes, and
+// returns the ParamsID found in the encoding. It returns an error if the
+// parameter set is not supported by this package, or if the length of b
+// does not match it; in that case, c is unmodified. The key is not
+// validated, see Validate.
+func (c *PublicKey) SetBytes(b []byte) (ParamsID, error) {
+	if len(b) == 0 {
+		return 0, errParamsLength
+	}
+	id := ParamsID(b[0])
+	if _, err := NegotiateParams(ParamsCSIDH512, id); err != nil {
+		return id, err
+	}
+	if len(b) != TaggedPublicKeySize {
+		return id, errParamsLength
+	}
+	var pub PublicKey
+	pub.Import(b[1:])
+	*c = pub
+	return id, nil
+}
@@ -0,0 +1,115 @@
+package csidh
+
+import (
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+const (
+	pemPublicKeyType  = "CSIDH PUBLIC KEY"
+	pemPrivateKeyType = "CSIDH PRIVATE KEY"
+	// pemParamsHeader is the header holding the name of the parameter set,
+	// as given by ParamsID.String.
+	pemParamsHeader = "Params"
+)
+
+var (
+	errPEMDecode    = errors.New("csidh: no PEM block found")
+	errPEMTrailing  = errors.New("csidh: trailing data after PEM block")
+	errPEMType      = errors.New("csidh: unexpected PEM block type")
+	errNonCanonical = errors.New("csidh: non-canonical public key")
+)
+
+// MarshalPEM returns the public key armored in a PEM block of type
+// "CSIDH PUBLIC KEY", whose Params header names the parameter set and whose
+// content is the encoding of the key given by Export.
+func (c *PublicKey) MarshalPEM() []byte {
+	b := make([]byte, PublicKeySize)
+	c.Export(b)
+	return pem.EncodeToMemory(&pem.Block{
+		Type:    pemPublicKeyType,
+		Headers: map[string]string{pemParamsHeader: ParamsCSIDH512.String()},
+		Bytes:   b,
+	})
+}
+
+// ParsePublicKeyPEM returns the public key armored by MarshalPEM. It returns
+// an error if data is not a single PEM block of the right type, if the
+// parameter set is not the one of this package, or if the key is not
+// canonical, i.e., its coefficient is not reduced. The key is not
+// validated, see Validate.
+func ParsePublicKeyPEM(data []byte) (*PublicKey, error) {
+	b, err := decodePEM(data, pemPublicKeyType)
+	if err != nil {
+		return nil, err
+	}
+	pub := new(PublicKey)
+	if !pub.Import(b) {
+		return nil, errParamsLength
+	}
+	if !isLess(&pub.a, &p) {
+		return nil, errNonCanonical
+	}
+	return pub, nil
+}
+
+// MarshalPEM returns the private key armored in a PEM block of type
+// "CSIDH PRIVATE KEY", whose Params header names the parameter set and whose
+// content is the encoding of the key given by Pack.
+func (c *PrivateKey) MarshalPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{
+		Type:    pemPrivateKeyType,
+		Headers: map[string]string{pemParamsHeader: ParamsCSIDH512.String()},
+		Bytes:   c.Pack(),
+	})
+}
+
+// ParsePrivateKeyPEM returns the private key armored by MarshalPEM. It
+// returns an error if data is not a single PEM block of the right type, if
+// the parameter set is not the one of this package, or if the key is
+// rejected by UnpackPrivateKey.
+func ParsePrivateKeyPEM(data []byte) (*PrivateKey, error) {
+	b, err := decodePEM(data, pemPrivateKeyType)
+	if err != nil {
+		return nil, err
+	}
+	return UnpackPrivateKey(b, &CSIDH512)
+}
+
+// decodePEM returns the content of the only PEM block in data, after
+// checking its type and its parameter set.
+func decodePEM(data []byte, blockType string) ([]byte, error) {
+	block, rest := pem.Decode(data)
+	if block == nil {
+		return nil, errPEMDecode
+	}
+	if len(rest) != 0 {
+		return nil, errPEMTrailing
+	}
+	if block.Type != blockType {
+		return nil, fmt.Errorf("%w: %q", errPEMType, block.Type)
+	}
+	name, ok := block.Headers[pemParamsHeader]
+	if !ok {
+		return nil, fmt.Errorf("csidh: missing %v header", pemParamsHeader)
+	}
+	id, ok := parseParamsName(name)
+	if !ok {
+		return nil, fmt.Errorf("csidh: unknown parameter set %q", name)
+	}
+	if _, err := NegotiateParams(ParamsCSIDH512, id); err != nil {
+		return nil, err
+	}
+	return block.Bytes, nil
+}
+
+// parseParamsName returns the ParamsID whose String method returns name.
+func parseParamsName(name string) (ParamsID, bool) {
+	for _, id := range []ParamsID{ParamsCSIDH512, ParamsCSIDH1024} {
+		if id.String() == name {
+			return id, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,364 @@
+package csidh
+
+import (
+	"io"
+	"time"
+)
+
+// radicalCount is the number of the smallest primes, 3 and 5, whose
+// isogenies are evaluated by groupAction as chains of radical isogenies, as
+// described by Castryck, Decru and Vercauteren in ia.cr/2020/1108. Since p
+// is congruent to -1 modulo both primes, every element of GF(p) has a unique
+// cube and fifth root, and each step of a chain costs an exponentiation
+// instead of a scalar multiplication to find a kernel point. The other
+// primes use Vélu's formulas, see xIso.
+const radicalCount = 2
+
+// radicalCofactor[i] is (p+1)/primes[i], which maps a point on a curve to
+// its rational primes[i]-torsion.
+var radicalCofactor = func() (k [radicalCount]fp) {
+	for i := range k {
+		k[i] = fp{4}
+		for j, v := range primes {
+			if j != i {
+				mul512(&k[i], &k[i], v)
+			}
+		}
+	}
+	return
+}()
+
+// weierstrass holds the coefficients of the curve
+// y^2 + a1*x*y + a3*y = x^3 + a2*x^2, on which the radical isogenies are
+// evaluated. The point (0,0) generates the kernel of the next isogeny.
+type weierstrass struct {
+	a1, a2, a3 fp
+}
+
+// ctLess64 returns 1 if x < y, and 0 otherwise, for x, y < 2^63.
+// Constant time.
+func ctLess64(x, y uint64) uint8 { return uint8((x - y) >> 63) }
+
+// radicalExps moves the exponents of the primes in primes[:radicalCount]
+// from e to the returned ones, clamped to [-expMax, expMax] as radicalAction
+// requires. The excess, which only imported keys can have, stays in e and is
+// evaluated with Vélu's formulas.
+func radicalExps(e *[primeCount]int16) (r [primeCount]int16) {
+	m := int16(expMax)
+	for i := 0; i < radicalCount; i++ {
+		r[i] = max(min(e[i], m), -m)
+		e[i] -= r[i]
+	}
+	return
+}
+
+// radicalAction evaluates the action of the exponents in e of the primes in
+// primes[:radicalCount] on the curve with coefficient a, and clears them from
+// e, so that the remaining exponents can be scheduled as usual. Each chain
+// performs expMax steps, of which those beyond the absolute value of the
+// exponent are discarded, so that the running time does not depend on it.
+// Hence, it requires |e[i]| <= expMax for i < radicalCount, and panics
+// otherwise instead of truncating the chain.
+// If isoTime is not nil, the time spent on each chain is added to it, as in
+// actionState.
+func radicalAction(a *fp, e *[primeCount]int16, gen *fpRngGen, rng io.Reader, isoTime *[primeCount]time.Duration) {
+	for i := 0; i < radicalCount; i++ {
+		// The branch is taken only on invalid input, so it does not leak
+		// valid exponents.
+		if e[i] < -int16(expMax) || e[i] > int16(expMax) {
+			panic("csidh: radical exponent out of range")
+		}
+	}
+	for i := 0; i < radicalCount; i++ {
+		start := time.Now()
+		x := int32(e[i])
+		neg := x >> 31
+		n := uint64((x ^ neg) - neg)
+
+		// Isogenies of negative exponents are evaluated on the twist, which
+		// is the curve with coefficient -a.
+		var negA fp
+		subRdc(&negA, &fp{}, a)
+		cswap512(a, &negA, uint8(neg&1))
+
+		var x0 fp
+		var w weierstrass
+		radicalKernel(&x0, a, &radicalCofactor[i], gen, rng)
+		switch primes[i] {
+		case 3:
+			w.radical3(a, &x0, n)
+		case 5:
+			w.radical5(a, &x0, n)
+		}
+		w.montgomery(a)
+
+		subRdc(&negA, &fp{}, a)
+		cswap512(a, &negA, uint8(neg&1))
+		e[i] = 0
+		if isoTime != nil {
+			isoTime[i] += time.Since(start)
+		}
+	}
+}
+
+// radicalKernel sets x to the affine x-coordinate of a point of order l on
+// the curve with coefficient a, where k = (p+1)/l.
+func radicalKernel(x, a, k *fp, gen *fpRngGen, rng io.Reader) {
+	co := coeff{a: *a, c: one}
+	for {
+		var P point
+		var rhs fp
+		gen.randFp(&P.x, rng)
+		P.z = one
+		montEval(&rhs, a, &P.x)
+		if rhs.isNonQuadRes() == 1 {
+			continue
+		}
+		ladderMul(&P, &P, &co, k, pbits)
+		if !P.z.isZero() {
+			modExpRdc512(&P.z, &P.z, &pMin1)
+			mulRdc(x, &P.x, &P.z)
+			return
+		}
+	}
+}
+
+// tangent sets f and df to the values of x^3 + a*x^2 + x and its derivative
+// at x.
+func tangent(f, df, a, x *fp) {
+	var t fp
+	montEval(f, a, x)
+	mulRdc(&t, x, x)
+	addRdc(df, &t, &t)
+	addRdc(df, df, &t)
+	mulRdc(&t, a, x)
+	addRdc(&t, &t, &t)
+	addRdc(df, df, &t)
+	addRdc(df, df, &one)
+}
+
+// radical3 sets w to the curve reached by n steps of the chain of
+// 3-isogenies starting at the curve with coefficient a, whose first kernel
+// is generated by the point with x-coordinate x0.
+func (w *weierstrass) radical3(a, x0 *fp, n uint64) {
+	// Moving the point P=(x0,y0) of order 3 to (0,0), and its tangent, which
+	// meets the curve only at P, to y=0, gives a1 = df/y0, a2 = 0 and
+	// a3 = 2*y0. Scaling by y0 then gives a1 = df and a3 = 2*f^2.
+	var f, df fp
+	tangent(&f, &df, a, x0)
+	w.a1 = df
+	w.a2 = fp{}
+	mulRdc(&w.a3, &f, &f)
+	addRdc(&w.a3, &w.a3, &w.a3)
+
+	for j := uint64(0); j < uint64(expMax); j++ {
+		var alpha, a1, a3, t fp
+		// alpha = cbrt(-a3)
+		subRdc(&t, &fp{}, &w.a3)
+		modExpRdc512(&alpha, &t, &cbrtExp)
+
+		// a1' = a1 - 6*alpha
+		addRdc(&t, &alpha, &alpha)
+		addRdc(&a1, &t, &alpha)
+		addRdc(&a1, &a1, &a1)
+		subRdc(&a1, &w.a1, &a1)
+
+		// a3' = alpha*(3*a1*alpha - a1^2) + 9*a3
+		mulRdc(&t, &w.a1, &alpha)
+		addRdc(&a3, &t, &t)
+		addRdc(&a3, &a3, &t)
+		mulRdc(&t, &w.a1, &w.a1)
+		subRdc(&a3, &a3, &t)
+		mulRdc(&a3, &a3, &alpha)
+		addRdc(&t, &w.a3, &w.a3)
+		addRdc(&t, &t, &t)
+		addRdc(&t, &t, &t)
+		addRdc(&t, &t, &w.a3)
+		addRdc(&a3, &a3, &t)
+
+		c := ctLess64(j, n)
+		cswap512(&w.a1, &a1, c)
+		cswap512(&w.a3, &a3, c)
+	}
+}
+
+// radical5 sets w to the curve reached by n steps of the chain of
+// 5-isogenies starting at the curve with coefficient a, whose first kernel
+// is generated by the point with x-coordinate x0.
+func (w *weierstrass) radical5(a, x0 *fp, n uint64) {
+	// The curve is kept in Tate normal form
+	// y^2 + (1-b)*x*y - b*y = x^3 - b*x^2. Moving P to (0,0) and its
+	// tangent to y=0 as in radical3 gives a2 = 3*x0 + a - df^2/(4*f), and
+	// scaling the curve so that a2 = a3 gives b = -a2^3/(4*f).
+	var f, df, b, num, den, t fp
+	tangent(&f, &df, a, x0)
+	addRdc(&t, x0, x0)
+	addRdc(&t, &t, x0)
+	addRdc(&t, &t, a)
+	mulRdc(&num, &f, &t)
+	addRdc(&num, &num, &num)
+	addRdc(&num, &num, &num)
+	mulRdc(&t, &df, &df)
+	subRdc(&num, &num, &t) // num = 4*f*a2
+	mulRdc(&t, &num, &num)
+	mulRdc(&num, &num, &t)
+	mulRdc(&den, &f, &f)
+	mulRdc(&den, &den, &den)
+	for i := 0; i < 8; i++ {
+		addRdc(&den, &den, &den)
+	}
+	modExpRdc512(&den, &den, &pMin1)
+	mulRdc(&b, &num, &den)
+	subRdc(&b, &fp{}, &b) // b = -(4*f*a2)^3/(256*f^4)
+
+	for j := uint64(0); j < uint64(expMax); j++ {
+		var alpha, alpha2, alpha3, alpha4, c fp
+		// alpha = b^(1/5)
+		modExpRdc512(&alpha, &b, &fifthRootExp)
+		mulRdc(&alpha2, &alpha, &alpha)
+		mulRdc(&alpha3, &alpha2, &alpha)
+		mulRdc(&alpha4, &alpha2, &alpha2)
+
+		// b' = alpha*(alpha^4 + 3*alpha^3 + 4*alpha^2 + 2*alpha + 1) /
+		//      (alpha^4 - 2*alpha^3 + 4*alpha^2 - 3*alpha + 1)
+		addRdc(&c, &alpha2, &alpha2)
+		addRdc(&c, &c, &c)
+		addRdc(&c, &c, &alpha4)
+		addRdc(&c, &c, &one) // c = alpha^4 + 4*alpha^2 + 1
+		addRdc(&t, &alpha3, &alpha)
+		addRdc(&num, &t, &t)
+		addRdc(&num, &num, &alpha3)
+		addRdc(&num, &num, &c)
+		mulRdc(&num, &num, &alpha)
+		addRdc(&t, &alpha3, &alpha3)
+		addRdc(&t, &t, &alpha)
+		addRdc(&t, &t, &alpha)
+		addRdc(&t, &t, &alpha)
+		subRdc(&den, &c, &t)
+		modExpRdc512(&den, &den, &pMin1)
+		mulRdc(&num, &num, &den)
+
+		cswap512(&b, &num, ctLess64(j, n))
+	}
+
+	subRdc(&w.a1, &one, &b)
+	subRdc(&w.a2, &fp{}, &b)
+	w.a3 = w.a2
+}
+
+// montgomery sets a to the coefficient of the Montgomery curve isomorphic
+// to w. The curve w is isomorphic to Y^2 = g(X) = X^3 + c2*X^2 + c1*X + c0,
+// with c2 = a1^2 + 4*a2, c1 = 8*a1*a3 and c0 = 16*a3^2, and g has a single
+// root r in GF(p), as the curve has a single point of order 2. Moving it to
+// X=0 gives X^3 + d2*X^2 + d1*X, which is isomorphic to the curve with
+// coefficient d2/s, where s is the square root of d1 that is a square.
+func (w *weierstrass) montgomery(a *fp) {
+	var g [3]fp
+	var t fp
+	mulRdc(&g[2], &w.a1, &w.a1)
+	addRdc(&t, &w.a2, &w.a2)
+	addRdc(&t, &t, &t)
+	addRdc(&g[2], &g[2], &t)
+	mulRdc(&g[1], &w.a1, &w.a3)
+	for i := 0; i < 3; i++ {
+		addRdc(&g[1], &g[1], &g[1])
+	}
+	mulRdc(&g[0], &w.a3, &w.a3)
+	for i := 0; i < 4; i++ {
+		addRdc(&g[0], &g[0], &g[0])
+	}
+
+	var r, d1, d2, s, sNeg fp
+	cubicRoot(&r, &g)
+	// d2 = 3*r + c2 and d1 = 3*r^2 + 2*c2*r + c1.
+	addRdc(&d2, &r, &r)
+	addRdc(&d2, &d2, &r)
+	addRdc(&d2, &d2, &g[2])
+	addRdc(&d1, &d2, &g[2])
+	mulRdc(&d1, &d1, &r)
+	addRdc(&d1, &d1, &g[1])
+
+	modExpRdc512(&s, &d1, &pPlus1By4)
+	subRdc(&sNeg, &fp{}, &s)
+	cswap512(&s, &sNeg, uint8(s.isNonQuadRes()))
+	modExpRdc512(&s, &s, &pMin1)
+	mulRdc(a, &d2, &s)
+}
+
+// cubicRoot sets r to the root in GF(p) of the polynomial
+// g(X) = X^3 + g[2]*X^2 + g[1]*X + g[0], which must have exactly one.
+// The root is the one of gcd(g, X^p - X).
+func cubicRoot(r *fp, g *[3]fp) {
+	// h = X^p mod g, by square-and-multiply on the bits of p.
+	h := [3]fp{{}, one, {}}
+	for i := pbits - 2; i >= 0; i-- {
+		cubicSqr(&h, g)
+		if (p[i/limbBitSize]>>(i%limbBitSize))&1 == 1 {
+			cubicMulX(&h, g)
+		}
+	}
+	subRdc(&h[1], &h[1], &one)
+
+	// For h = h2*X^2 + h1*X + h0, g mod h is (L1*X + L0)/h2^2, where
+	//   L1 = h1^2 - h0*h2 - g2*h1*h2 + g1*h2^2,
+	//   L0 = h1*h0 - g2*h0*h2 + g0*h2^2,
+	// and it has the root r = -L0/L1. It also holds if h2 = 0.
+	var l0, l1, h22, t fp
+	mulRdc(&h22, &h[2], &h[2])
+	mulRdc(&l1, &h[1], &h[1])
+	mulRdc(&t, &h[0], &h[2])
+	subRdc(&l1, &l1, &t)
+	mulRdc(&l0, &g[2], &t)
+	mulRdc(&t, &h[1], &h[2])
+	mulRdc(&t, &t, &g[2])
+	subRdc(&l1, &l1, &t)
+	mulRdc(&t, &g[1], &h22)
+	addRdc(&l1, &l1, &t)
+
+	mulRdc(&t, &h[1], &h[0])
+	subRdc(&l0, &t, &l0)
+	mulRdc(&t, &g[0], &h22)
+	addRdc(&l0, &l0, &t)
+
+	modExpRdc512(&l1, &l1, &pMin1)
+	mulRdc(r, &l0, &l1)
+	subRdc(r, &fp{}, r)
+}
+
+// cubicSqr sets h = h^2 mod g, where g is monic of degree 3.
+func cubicSqr(h, g *[3]fp) {
+	var w [5]fp
+	var t fp
+	mulRdc(&w[0], &h[0], &h[0])
+	mulRdc(&w[1], &h[0], &h[1])
+	addRdc(&w[1], &w[1], &w[1])
+	mulRdc(&w[2], &h[1], &h[1])
+	mulRdc(&t, &h[0], &h[2])
+	addRdc(&w[2], &w[2], &t)
+	addRdc(&w[2], &w[2], &t)
+	mulRdc(&w[3], &h[1], &h[2])
+	addRdc(&w[3], &w[3], &w[3])
+	mulRdc(&w[4], &h[2], &h[2])
+
+	// X^3 = -(g2*X^2 + g1*X + g0)
+	for i := 4; i >= 3; i-- {
+		for j := 0; j < 3; j++ {
+			mulRdc(&t, &g[j], &w[i])
+			subRdc(&w[i-3+j], &w[i-3+j], &t)
+		}
+	}
+	copy(h[:], w[:3])
+}
+
+// cubicMulX sets h = X*h mod g, where g is monic of degree 3.
+func cubicMulX(h, g *[3]fp) {
+	var t fp
+	h2 := h[2]
+	h[2] = h[1]
+	h[1] = h[0]
+	h[0] = fp{}
+	for j := 0; j < 3; j++ {
+		mulRdc(&t, &g[j], &h2)
+		subRdc(&h[j], &h[j], &t)
+	}
+}
@@ -0,0 +1,202 @@
+package csidh
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// Signatures based on the CSIDH group action, following the SeaSign
+// identification scheme with rejection sampling (ia.cr/2018/824), and the
+// Fiat-Shamir transform over SHAKE-256.
+//
+// The prover commits to E = [f]E_0, where the exponents of f are sampled
+// from [-(Delta+1)*5, (Delta+1)*5]. On challenge 0, it reveals z = f; on
+// challenge 1, it reveals z = f - e, where e is the private key, but only
+// if every exponent of z lies in [-Delta*5, Delta*5], so that z does not
+// leak e. Otherwise, the signer starts over. The verifier checks that [z]E_0
+// or [z]E_A, respectively, is equal to E.
+//
+// Since the relation lattice of the class group is not used, exponents are
+// not reduced, so signing and verifying take time proportional to Delta.
+// Hence, no parameters for a practical security level are provided: 128
+// bits of soundness need Rounds=128 and a Delta in the thousands, so that
+// signing would take days.
+
+// signParams are the parameters of the signature scheme.
+type signParams struct {
+	// Rounds is the number of parallel rounds, each one giving one bit of
+	// soundness.
+	Rounds int
+	// Delta determines the range of the exponents of the commitments, and
+	// thus the probability of restarting the signing process, which is
+	// about 1-exp(-37*Rounds/Delta).
+	Delta int
+}
+
+var (
+	errSignParams = errors.New("csidh: invalid signature parameters")
+	errSignTries  = errors.New("csidh: signing exceeded the number of attempts")
+)
+
+const (
+	// signChallengeSize is the size in bytes of the challenge hash.
+	signChallengeSize = 32
+	// signResponseSize is the size in bytes of the response of a round.
+	signResponseSize = 2 * primeCount
+	// signMaxTries bounds the number of times signing starts over.
+	signMaxTries = 1 << 10
+	// signDomain is the customization string of the challenge hash.
+	signDomain = "CSIDH-SeaSign"
+)
+
+func (p *signParams) isValid() bool {
+	return p != nil && p.Rounds > 0 && p.Delta > 0 &&
+		(p.Delta+1)*int(expMax) <= 1<<15-1
+}
+
+// signatureSize returns the size in bytes of the signatures produced with
+// the parameters p.
+func (p *signParams) signatureSize() int {
+	return signChallengeSize + p.Rounds*signResponseSize
+}
+
+// signCSIDH signs the message with the private key. The rng is used to
+// sample the commitments and the points needed by the group action.
+// Signing starts over until every response falls in the allowed range; it
+// returns an error if this does not happen after many attempts.
+func signCSIDH(priv *PrivateKey, message []byte, params *signParams, rng io.Reader) ([]byte, error) {
+	if !params.isValid() {
+		return nil, errSignParams
+	}
+
+	var pub PublicKey
+	GeneratePublicKey(&pub, priv, rng)
+
+	e := priv.exponents()
+	t := params.Rounds
+	f := make([][primeCount]int16, t)
+	commits := make([]PublicKey, t)
+	sig := make([]byte, params.signatureSize())
+	bound := int16(params.Delta * int(expMax))
+
+	for try := 0; try < signMaxTries; try++ {
+		for i := range f {
+			if err := sampleExponents(&f[i], bound+int16(expMax), rng); err != nil {
+				return nil, err
+			}
+			var s actionState
+			s.scheduleExps(&f[i])
+			commits[i] = PublicKey{}
+			s.run(&commits[i].a, &priv.fpRngGen, rng)
+		}
+
+		c := signChallenge(sig[:signChallengeSize], &pub, commits, message)
+		ok := true
+		for i := 0; i < t && ok; i++ {
+			z := f[i]
+			if c[i/8]>>(i%8)&1 == 1 {
+				for j := range z {
+					z[j] -= e[j]
+					ok = ok && -bound <= z[j] && z[j] <= bound
+				}
+			}
+			resp := sig[signChallengeSize+i*signResponseSize:]
+			for j := range z {
+				binary.LittleEndian.PutUint16(resp[2*j:], uint16(z[j]))
+			}
+		}
+		if ok {
+			return sig, nil
+		}
+	}
+	return nil, errSignTries
+}
+
+// verifyCSIDH returns true if sig is a valid signature of the message under
+// the public key, which is validated first. The rng is used by the group
+// action and the validation of the public key.
+func verifyCSIDH(pub *PublicKey, message, sig []byte, params *signParams, rng io.Reader) bool {
+	if !params.isValid() || len(sig) != params.signatureSize() || !Validate(pub, rng) {
+		return false
+	}
+
+	var gen fpRngGen
+	t := params.Rounds
+	c := make([]byte, (t+7)/8)
+	expandChallenge(c, sig[:signChallengeSize])
+	commits := make([]PublicKey, t)
+	bound := int16(params.Delta * int(expMax))
+
+	for i := range commits {
+		var z [primeCount]int16
+		b := c[i/8] >> (i % 8) & 1
+		max := bound + int16(expMax)*int16(1-b)
+		resp := sig[signChallengeSize+i*signResponseSize:]
+		for j := range z {
+			z[j] = int16(binary.LittleEndian.Uint16(resp[2*j:]))
+			if z[j] < -max || z[j] > max {
+				return false
+			}
+		}
+
+		if b == 1 {
+			commits[i] = *pub
+		}
+		var s actionState
+		s.scheduleExps(&z)
+		s.run(&commits[i].a, &gen, rng)
+	}
+
+	var h [signChallengeSize]byte
+	signChallenge(h[:], pub, commits, message)
+	return bytes.Equal(h[:], sig[:signChallengeSize])
+}
+
+// signChallenge computes the challenge hash into h, and returns it
+// expanded to one bit per commitment.
+func signChallenge(h []byte, pub *PublicKey, commits []PublicKey, message []byte) []byte {
+	var buf [PublicKeySize]byte
+	d := sha3.NewCShake256(nil, []byte(signDomain))
+	pub.Export(buf[:])
+	_, _ = d.Write(buf[:])
+	for i := range commits {
+		commits[i].Export(buf[:])
+		_, _ = d.Write(buf[:])
+	}
+	_, _ = d.Write(message)
+	_, _ = d.Read(h)
+
+	c := make([]byte, (len(commits)+7)/8)
+	expandChallenge(c, h)
+	return c
+}
+
+// expandChallenge expands the challenge hash h into c.
+func expandChallenge(c, h []byte) {
+	x := sha3.NewShake256()
+	_, _ = x.Write(h)
+	_, _ = x.Read(c)
+}
+
+// sampleExponents samples each exponent of f uniformly from [-max, max].
+func sampleExponents(f *[primeCount]int16, max int16, rng io.Reader) error {
+	var buf [2]byte
+	n := uint32(2*int32(max) + 1)
+	limit := (1 << 16) / n * n
+	for i := range f {
+		for {
+			if _, err := io.ReadFull(rng, buf[:]); err != nil {
+				return err
+			}
+			if v := uint32(binary.LittleEndian.Uint16(buf[:])); v < limit {
+				f[i] = int16(v%n) - max
+				break
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package csidh
+
+import (
+	"testing"
+
+	. "github.com/cloudflare/circl/internal/test"
+)
+
+// Parameters for testing only: a single round gives one bit of soundness.
+var testSignParams = signParams{Rounds: 1, Delta: 20}
+
+func TestSignCSIDH(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping signatures in short mode")
+	}
+	var prv PrivateKey
+	var pub PublicKey
+	msg := []byte("message")
+	params := &testSignParams
+
+	CheckNoErr(t, GeneratePrivateKey(&prv, rng), "PrivateKey generation failed")
+	GeneratePublicKey(&pub, &prv, rng)
+
+	sig, err := signCSIDH(&prv, msg, params, rng)
+	CheckNoErr(t, err, "Signing failed")
+	if len(sig) != params.signatureSize() {
+		t.Fatalf("got signature size %v, want %v", len(sig), params.signatureSize())
+	}
+	CheckOk(verifyCSIDH(&pub, msg, sig, params, rng), "Valid signature rejected", t)
+	CheckOk(!verifyCSIDH(&pub, []byte("other message"), sig, params, rng), "Signature of other message accepted", t)
+
+	// Responses out of range, and malformed inputs.
+	bad := append([]byte{}, sig...)
+	bad[len(bad)-1] = 0x7f
+	CheckOk(!verifyCSIDH(&pub, msg, bad, params, rng), "Tampered signature accepted", t)
+	CheckOk(!verifyCSIDH(&pub, msg, sig[:len(sig)-1], params, rng), "Short signature accepted", t)
+	CheckOk(!verifyCSIDH(&PublicKey{a: two}, msg, sig, params, rng), "Invalid public key accepted", t)
+	CheckOk(!verifyCSIDH(&pub, msg, sig, &signParams{}, rng), "Invalid parameters accepted", t)
+
+	_, err = signCSIDH(&prv, msg, &signParams{Rounds: 1, Delta: 1 << 15}, rng)
+	CheckIsErr(t, err, "Signing must fail with invalid parameters")
+}
@@ -2,6 +2,8 @@ package bls12381
 
 import (
 	"bytes"
+	"encoding/hex"
+	"fmt"
 	"io"
 	"os"
 	"testing"
@@ -87,3 +89,90 @@ func TestSerializationVector(t *testing.T) {
 		t.Run(v.fileName[:7], func(t *testing.T) { testSerialVector(t, file, &v) })
 	}
 }
+
+func TestSerializationFlags(t *testing.T) {
+	const (
+		g1Gen = "97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb"
+		g2Gen = "93e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8"
+	)
+	infinity := func(n int, compressed bool) []byte {
+		b := make([]byte, n)
+		b[0] = 0x40
+		if compressed {
+			b[0] |= 0x80
+		}
+		return b
+	}
+
+	type point interface {
+		SetIdentity()
+		SetBytes([]byte) error
+		Bytes() []byte
+		BytesCompressed() []byte
+		IsIdentity() bool
+	}
+	for _, v := range []struct {
+		name           string
+		gen, id, Q     point
+		genHex         string
+		size, sizeComp int
+	}{
+		{"G1", G1Generator(), new(G1), new(G1), g1Gen, G1Size, G1SizeCompressed},
+		{"G2", G2Generator(), new(G2), new(G2), g2Gen, G2Size, G2SizeCompressed},
+	} {
+		t.Run(v.name, func(t *testing.T) {
+			gen, id, Q := v.gen, v.id, v.Q
+			want, _ := hex.DecodeString(v.genHex)
+			got := gen.BytesCompressed()
+			if !bytes.Equal(got, want) {
+				test.ReportError(t, got, want)
+			}
+			test.CheckNoErr(t, Q.SetBytes(want), "failed deserialization")
+			if !isEqual(Q, gen) {
+				test.ReportError(t, Q, gen)
+			}
+
+			id.SetIdentity()
+			for _, c := range []bool{false, true} {
+				n := v.size
+				got := id.Bytes()
+				if c {
+					n = v.sizeComp
+					got = id.BytesCompressed()
+				}
+				want := infinity(n, c)
+				if !bytes.Equal(got, want) {
+					test.ReportError(t, got, want, c)
+				}
+				test.CheckNoErr(t, Q.SetBytes(want), "failed deserialization")
+				test.CheckOk(Q.IsIdentity(), "identity was expected", t)
+			}
+
+			// Malformed encodings.
+			bigY := bytes.Clone(gen.Bytes())
+			bigY[0] |= 0x20
+			badInf := infinity(v.sizeComp, true)
+			badInf[5] = 1
+			// Uncompressed infinity with the length of a compressed point.
+			shortInf := infinity(v.sizeComp, false)
+			badX := bytes.Clone(want)
+			badX[len(badX)-1] ^= 1
+			for i, b := range [][]byte{
+				want[:v.sizeComp-1],
+				gen.Bytes()[:v.size-1],
+				bigY,
+				infinity(v.sizeComp, true)[:v.sizeComp-1],
+				shortInf,
+				badInf,
+				badX,
+			} {
+				test.CheckIsErr(t, Q.SetBytes(b), fmt.Sprintf("deserialization must fail: %v", i))
+			}
+			for _, prefix := range []byte{0x20, 0x60, 0xE0} {
+				b := bytes.Clone(want)
+				b[0] = b[0]&0x1F | prefix
+				test.CheckIsErr(t, Q.SetBytes(b), "deserialization must fail")
+			}
+		})
+	}
+}
@@ -5,18 +5,37 @@ import (
 	"crypto/rand"
 	"crypto/subtle"
 	"errors"
+	"fmt"
 	"io"
 	"math/big"
 
 	"github.com/cloudflare/circl/internal/conv"
 )
 
+// Errors returned by the decoding functions of this package. They are
+// wrapped with the name of the type being decoded, so they must be checked
+// using errors.Is.
 var (
-	errInputLength = errors.New("incorrect input length")
-	errInputRange  = errors.New("value out of range [0,order)")
-	errInputString = errors.New("invalid string")
+	// ErrWrongLength is returned when the input is too short.
+	ErrWrongLength = errors.New("incorrect input length")
+	// ErrFieldNotCanonical is returned when a coordinate is not in the
+	// range [0,order).
+	ErrFieldNotCanonical = errors.New("value out of range [0,order)")
+	// ErrNotInSubgroup is returned when an element does not belong to the
+	// subgroup of the type being decoded.
+	ErrNotInSubgroup = errors.New("element not in subgroup")
 )
 
+var errInputString = errors.New("invalid string")
+
+// decodeError annotates a non-nil err with the name of the type being decoded.
+func decodeError(name string, err error) error {
+	if err != nil {
+		err = fmt.Errorf("ff: %v: %w", name, err)
+	}
+	return err
+}
+
 func errFirst(e ...error) (err error) {
 	for i := 0; i < len(e); i++ {
 		if e[i] != nil {
@@ -32,7 +51,7 @@ func setString(in string, order []byte) ([]uint64, error) {
 		return nil, errInputString
 	}
 	if inBig.Sign() < 0 || inBig.Cmp(new(big.Int).SetBytes(order)) >= 0 {
-		return nil, errInputRange
+		return nil, ErrFieldNotCanonical
 	}
 	inBytes := inBig.FillBytes(make([]byte, len(order)))
 	return setBytesBounded(inBytes, order)
@@ -40,7 +59,7 @@ func setString(in string, order []byte) ([]uint64, error) {
 
 func setBytesBounded(in []byte, order []byte) ([]uint64, error) {
 	if isLessThan(in, order) == 0 {
-		return nil, errInputRange
+		return nil, ErrFieldNotCanonical
 	}
 	return conv.BytesBe2Uint64Le(in), nil
 }
@@ -82,3 +101,8 @@ func ctUint64Eq(x, y []uint64) (b int) {
 }
 
 func cselectU64(z *uint64, b, x, y uint64) { *z = (x &^ (-b)) | (y & (-b)) }
+
+// cmpLex combines the comparisons hi and lo, both in {-1, 0, 1}, of the most
+// and least significant parts of two values. It returns hi if hi != 0, and lo
+// otherwise, in constant time.
+func cmpLex(hi, lo int) int { return hi | (lo &^ -(hi & 1)) }
@@ -0,0 +1,88 @@
+package ff
+
+import (
+	"encoding"
+	"errors"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+func TestDecodingErrors(t *testing.T) {
+	const testTimes = 1 << 6
+	// nonCanonical returns a valid encoding of x, with its first coordinate
+	// replaced by FpOrder.
+	nonCanonical := func(x encoding.BinaryMarshaler) []byte {
+		b, err := x.MarshalBinary()
+		test.CheckNoErr(t, err, "MarshalBinary failed")
+		copy(b, FpOrder())
+		return b
+	}
+
+	for _, c := range []struct {
+		name  string
+		dec   encoding.BinaryUnmarshaler
+		input []byte
+		want  error
+	}{
+		{"Fp_short", new(Fp), make([]byte, FpSize-1), ErrWrongLength},
+		{"Fp_range", new(Fp), FpOrder(), ErrFieldNotCanonical},
+		{"Fp2_short", new(Fp2), make([]byte, Fp2Size-1), ErrWrongLength},
+		{"Fp2_range", new(Fp2), nonCanonical(randomFp2(t)), ErrFieldNotCanonical},
+		{"Fp6_short", new(Fp6), make([]byte, Fp6Size-1), ErrWrongLength},
+		{"Fp6_range", new(Fp6), nonCanonical(randomFp6(t)), ErrFieldNotCanonical},
+		{"Fp12_short", new(Fp12), make([]byte, Fp12Size-1), ErrWrongLength},
+		{"Fp12_range", new(Fp12), nonCanonical(randomFp12(t)), ErrFieldNotCanonical},
+		{"Scalar_short", new(Scalar), make([]byte, ScalarSize-1), ErrWrongLength},
+		{"Scalar_range", new(Scalar), ScalarOrder(), ErrFieldNotCanonical},
+		{"Cyclo6_short", new(Cyclo6), make([]byte, Fp12Size-1), ErrWrongLength},
+		{"Cyclo6_range", new(Cyclo6), nonCanonical(randomCyclo6(t)), ErrFieldNotCanonical},
+		{"Cyclo6_zero", new(Cyclo6), make([]byte, Fp12Size), ErrNotInSubgroup},
+		{"URoot_short", new(URoot), make([]byte, URootSize-1), ErrWrongLength},
+		{"URoot_range", new(URoot), nonCanonical(randomURoot(t)), ErrFieldNotCanonical},
+	} {
+		err := c.dec.UnmarshalBinary(c.input)
+		if !errors.Is(err, c.want) {
+			test.ReportError(t, err, c.want, c.name)
+		}
+	}
+
+	t.Run("subgroup", func(t *testing.T) {
+		var c Cyclo6
+		b, _ := randomFp12(t).MarshalBinary()
+		err := c.UnmarshalBinary(b)
+		if !errors.Is(err, ErrNotInSubgroup) {
+			test.ReportError(t, err, ErrNotInSubgroup)
+		}
+
+		var u URoot
+		for i := 0; i < testTimes; i++ {
+			x := randomCyclo6(t)
+			b, _ = x.MarshalBinary()
+			err = u.UnmarshalBinary(b)
+			if !errors.Is(err, ErrNotInSubgroup) {
+				test.ReportError(t, err, ErrNotInSubgroup, x)
+			}
+		}
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		var c Cyclo6
+		x := randomCyclo6(t)
+		b, _ := x.MarshalBinary()
+		test.CheckNoErr(t, c.UnmarshalBinary(b), "Cyclo6 decoding failed")
+		if c.IsEqual(x) == 0 {
+			test.ReportError(t, c, x)
+		}
+
+		var u URoot
+		for i := 0; i < testTimes; i++ {
+			y := randomURoot(t)
+			b, _ = y.MarshalBinary()
+			test.CheckNoErr(t, u.UnmarshalBinary(b), "URoot decoding failed")
+			if u.IsEqual(y) == 0 {
+				test.ReportError(t, u, y)
+			}
+		}
+	})
+}
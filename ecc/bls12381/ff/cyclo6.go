@@ -1,15 +1,72 @@
 package ff
 
-// Cyclo6 represents an element of the 6th cyclotomic group.
+import (
+	"math/big"
+
+	"github.com/cloudflare/circl/math"
+)
+
+// Cyclo6 represents an element of the 6th cyclotomic group. As for Fp12,
+// IsEqual requires both elements to be in canonical form (see Normalize).
 type Cyclo6 Fp12
 
 func (z Cyclo6) String() string           { return (Fp12)(z).String() }
 func (z Cyclo6) IsEqual(x *Cyclo6) int    { return (Fp12)(z).IsEqual((*Fp12)(x)) }
 func (z Cyclo6) IsIdentity() int          { i := &Fp12{}; i.SetOne(); return z.IsEqual((*Cyclo6)(i)) }
+func (z *Cyclo6) Normalize()              { (*Fp12)(z).Normalize() }
 func (z *Cyclo6) Frob(x *Cyclo6)          { (*Fp12)(z).Frob((*Fp12)(x)) }
 func (z *Cyclo6) Mul(x, y *Cyclo6)        { (*Fp12)(z).Mul((*Fp12)(x), (*Fp12)(y)) }
 func (z *Cyclo6) Inv(x *Cyclo6)           { *z = *x; z[1].Neg() }
 func (z *Cyclo6) exp(x *Cyclo6, n []byte) { (*Fp12)(z).Exp((*Fp12)(x), n) }
+
+// EqualCT returns 1 if z and x are equal, and 0 otherwise, in constant
+// time. See Fp12.EqualCT.
+func (z *Cyclo6) EqualCT(x *Cyclo6) int { return (*Fp12)(z).EqualCT((*Fp12)(x)) }
+
+// MarshalBinary returns a slice of Fp12Size bytes, as for Fp12.
+func (z Cyclo6) MarshalBinary() ([]byte, error) { return (Fp12)(z).MarshalBinary() }
+
+// UnmarshalBinary reconstructs a Cyclo6 from a slice that must have at least
+// Fp12Size bytes. It returns ErrNotInSubgroup if the element does not belong
+// to the 6-th cyclotomic group, in which case z is not modified.
+func (z *Cyclo6) UnmarshalBinary(b []byte) error {
+	var x Fp12
+	if err := x.UnmarshalBinary(b); err != nil {
+		return err
+	}
+	if (*Cyclo6)(&x).isInSubgroup() == 0 {
+		return decodeError("Cyclo6", ErrNotInSubgroup)
+	}
+	*z = (Cyclo6)(x)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder, returning the encoding of
+// MarshalBinary.
+func (z *Cyclo6) GobEncode() ([]byte, error) { return z.MarshalBinary() }
+
+// GobDecode implements gob.GobDecoder. It is as UnmarshalBinary, except that
+// b must have exactly Fp12Size bytes.
+func (z *Cyclo6) GobDecode(b []byte) error {
+	if len(b) != Fp12Size {
+		return decodeError("Cyclo6", ErrWrongLength)
+	}
+	return z.UnmarshalBinary(b)
+}
+
+// isInSubgroup returns 1 if z is a non-zero element satisfying
+// z^(p^4-p^2+1) = 1, i.e., z^(p^4)*z = z^(p^2); otherwise returns 0.
+func (z *Cyclo6) isInSubgroup() int {
+	var p2, p4 Fp12
+	x := (*Fp12)(z)
+	p2.Frob(x)
+	p2.Frob(&p2)
+	p4.Frob(&p2)
+	p4.Frob(&p4)
+	p4.Mul(&p4, x)
+	return (1 - x.IsZero()) & p4.IsEqual(&p2)
+}
+
 func (z *Cyclo6) Sqr(x *Cyclo6) {
 	// Method of Granger-Scott.
 	// Page 7 of "Faster Squaring in the Cyclotomic Subgroup of Sixth Degree Extensions"
@@ -47,21 +104,97 @@ func (z *Cyclo6) Sqr(x *Cyclo6) {
 	(*Fp12)(z).FromFp12Cubic(&zz)
 }
 
+// MulConj calculates z = x*y^-1, which for elements of Cyclo6 is the
+// product of x and the conjugate of y. The conjugate of y is not computed,
+// but its sign is folded into the Karatsuba multiplication.
+func (z *Cyclo6) MulConj(x, y *Cyclo6) {
+	var x0y0, x1y1, sx, dy, k Fp6
+	x0y0.Mul(&x[0], &y[0])
+	x1y1.Mul(&x[1], &y[1])
+	sx.Add(&x[0], &x[1])
+	dy.Sub(&y[0], &y[1])
+	k.Mul(&sx, &dy)
+	z[1].Sub(&k, &x0y0)
+	z[1].Add(&z[1], &x1y1)
+	x1y1.MulBeta()
+	z[0].Sub(&x0y0, &x1y1)
+}
+
 // PowToX computes z = x^paramX, where paramX is the parameter of the BLS curve.
-func (z *Cyclo6) PowToX(x *Cyclo6) {
+func (z *Cyclo6) PowToX(x *Cyclo6) { z.powToX(x, false) }
+
+// powToX is as PowToX, with the squarings selected by lucas as in sqrN.
+func (z *Cyclo6) powToX(x *Cyclo6, lucas bool) {
 	t := new(Cyclo6)
 	*t = *x
-	const lenX = 64
-	for i := lenX - 2; i >= 0; i-- {
-		t.Sqr(t)
-		// paramX is -2 ^ 63 - 2 ^ 62 - 2 ^ 60 - 2 ^ 57 - 2 ^ 48 - 2 ^ 16
-		if (i == 62) || (i == 60) || (i == 57) || (i == 48) || (i == 16) {
-			t.Mul(t, x)
-		}
+	// paramX is -2 ^ 63 - 2 ^ 62 - 2 ^ 60 - 2 ^ 57 - 2 ^ 48 - 2 ^ 16, so the
+	// chain consists of runs of squarings, each one followed by a product.
+	for _, n := range [...]int{1, 2, 3, 9, 32} {
+		t.sqrN(t, n, lucas)
+		t.Mul(t, x)
 	}
+	t.sqrN(t, 16, lucas)
 	z.Inv(t)
 }
 
+// multiExpOmega is the window size of the wNAF recoding used by MultiExp.
+const multiExpOmega = 4
+
+// MultiExp calculates z = \Prod_i bases[i]^exponents[i], where the exponents
+// are in big-endian order. It uses Straus' method with a squaring chain
+// shared by all the bases, and signed digits in wNAF form, since inversion
+// in the cyclotomic group is a conjugation. It runs in variable time, so
+// exponents must be public.
+func (z *Cyclo6) MultiExp(bases []*Cyclo6, exponents [][]byte) {
+	if len(bases) != len(exponents) {
+		panic("mismatch length of inputs")
+	}
+
+	const tableSize = 1 << (multiExpOmega - 2)
+	tables := make([][tableSize]Cyclo6, len(bases))
+	nafs := make([][]int32, len(bases))
+	maxLen := 0
+	for i, g := range bases {
+		var g2 Cyclo6
+		g2.Sqr(g)
+		tables[i][0] = *g
+		for j := 1; j < tableSize; j++ {
+			tables[i][j].Mul(&tables[i][j-1], &g2)
+		}
+		nafs[i] = math.OmegaNAF(new(big.Int).SetBytes(exponents[i]), multiExpOmega)
+		if len(nafs[i]) > maxLen {
+			maxLen = len(nafs[i])
+		}
+	}
+
+	var zz, t Cyclo6
+	(*Fp12)(&zz).SetOne()
+	for k := maxLen - 1; k >= 0; k-- {
+		zz.Sqr(&zz)
+		for i := range nafs {
+			if k >= len(nafs[i]) {
+				continue
+			}
+			if d := nafs[i][k]; d > 0 {
+				zz.Mul(&zz, &tables[i][d>>1])
+			} else if d < 0 {
+				t.Inv(&tables[i][(-d)>>1])
+				zz.Mul(&zz, &t)
+			}
+		}
+	}
+	*z = zz
+}
+
+// Cyclo6FromSeed returns an element of the 6-th cyclotomic group derived
+// deterministically from the seed, which is the EasyExponentiation of
+// Fp12FromSeed(seed).
+func Cyclo6FromSeed(seed []byte) *Cyclo6 {
+	z := new(Cyclo6)
+	EasyExponentiation(z, Fp12FromSeed(seed))
+	return z
+}
+
 // EasyExponentiation calculates g = f^(p^6-1)(p^2+1), where g becomes an
 // element of the 6-th cyclotomic group.
 func EasyExponentiation(g *Cyclo6, f *Fp12) {
@@ -83,22 +216,19 @@ func EasyExponentiation(g *Cyclo6, f *Fp12) {
 
 // HardExponentiation calculates u = g^(Cy_6(p)/r), where u is a root of unity.
 func HardExponentiation(u *URoot, g *Cyclo6) {
-	var t0, t1, _g, g3 Cyclo6
+	var t0, t1, g3 Cyclo6
 	var c, a0, a1, a2, a3 Cyclo6
-	_g.Inv(g)        // _g = g^-1
-	g3.Sqr(g)        // g3 = g^2
-	g3.Mul(&g3, g)   // g3 = g^3
-	t0.PowToX(g)     // t0 = g^x
-	t0.Mul(&t0, &_g) // t0 = g^(x-1)
-	t1.PowToX(&t0)   // t1 = g^(x-1)*x
-	t0.Inv(&t0)      // t0 = g^-(x-1)
-	a3.Mul(&t1, &t0) // a3 = g^(x-1)*(x-1)
-	a2.Frob(&a3)     // a2 = a3*p
-	a1.Frob(&a2)     // a1 = a2*p = a3*p^2
-	t0.Inv(&a3)      // t0 = -a3
-	a1.Mul(&a1, &t0) // a1 = a3*p^2-a3
-	a0.Frob(&a1)     // a0 = a3*p^3-a3*p
-	a0.Mul(&a0, &g3) // a0 = a3*p^3-a3*p+3
+	g3.Sqr(g)            // g3 = g^2
+	g3.Mul(&g3, g)       // g3 = g^3
+	t0.PowToX(g)         // t0 = g^x
+	t0.MulConj(&t0, g)   // t0 = g^(x-1)
+	t1.PowToX(&t0)       // t1 = g^(x-1)*x
+	a3.MulConj(&t1, &t0) // a3 = g^(x-1)*(x-1)
+	a2.Frob(&a3)         // a2 = a3*p
+	a1.Frob(&a2)         // a1 = a2*p = a3*p^2
+	a1.MulConj(&a1, &a3) // a1 = a3*p^2-a3
+	a0.Frob(&a1)         // a0 = a3*p^3-a3*p
+	a0.Mul(&a0, &g3)     // a0 = a3*p^3-a3*p+3
 
 	c.PowToX(&a3)  // c = g^(a3*x)
 	c.Mul(&c, &a2) // c = g^(a3*x+a2)
@@ -1,6 +1,12 @@
 package ff
 
 import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/gob"
+	"errors"
+	"fmt"
 	"math/big"
 	"testing"
 
@@ -82,6 +88,33 @@ func TestCyclo6(t *testing.T) {
 			}
 		}
 	})
+	t.Run("mul_conj", func(t *testing.T) {
+		var want, got, yInv Cyclo6
+		for i := 0; i < testTimes; i++ {
+			x := randomCyclo6(t)
+			y := randomCyclo6(t)
+
+			// x*conj(y) = x*y^-1
+			got.MulConj(x, y)
+			yInv.Inv(y)
+			want.Mul(x, &yInv)
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x, y)
+			}
+
+			// Aliasing of the output with either input.
+			got = *x
+			got.MulConj(&got, y)
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x, y)
+			}
+			got = *y
+			got.MulConj(x, &got)
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x, y)
+			}
+		}
+	})
 	t.Run("sqr_sqrfasr", func(t *testing.T) {
 		var want, got Cyclo6
 		for i := 0; i < testTimes; i++ {
@@ -96,6 +129,60 @@ func TestCyclo6(t *testing.T) {
 		}
 	})
 
+	t.Run("lucas_sqr", func(t *testing.T) {
+		var want, got, one Cyclo6
+		(*Fp12)(&one).SetOne()
+		minusOne := one
+		(*Fp12)(&minusOne).Neg()
+		for i := 0; i < testTimes; i++ {
+			x := randomCyclo6(t)
+			switch i {
+			case 0:
+				x = &one
+			case 1:
+				x = &minusOne
+			}
+
+			// The generic Fp12 square also covers x = -1, which has norm
+			// one but is not in Cyclo6, so Sqr does not apply to it.
+			got.LucasSqr(x)
+			(*Fp12)(&want).Sqr((*Fp12)(x))
+			got.Normalize()
+			want.Normalize()
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x)
+			}
+
+			// decompress(sqr^n(compress(x))) = x^(2^n)
+			var l cycloLucas
+			l.compress(x)
+			want = *x
+			for j := 0; j < 16; j++ {
+				l.sqr()
+				(*Fp12)(&want).Sqr((*Fp12)(&want))
+			}
+			l.decompress(&got)
+			got.Normalize()
+			want.Normalize()
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x)
+			}
+		}
+	})
+	t.Run("powToX_lucas", func(t *testing.T) {
+		var want, got Cyclo6
+		for i := 0; i < testTimes; i++ {
+			x := randomCyclo6(t)
+
+			want.PowToX(x)
+			got.powToX(x, true)
+			got.Normalize()
+			want.Normalize()
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x)
+			}
+		}
+	})
 	t.Run("invFp12_vs_invCyclo6", func(t *testing.T) {
 		var want, got Fp12
 		var y Cyclo6
@@ -111,6 +198,88 @@ func TestCyclo6(t *testing.T) {
 			}
 		}
 	})
+	t.Run("from_seed", func(t *testing.T) {
+		x := Cyclo6FromSeed([]byte("circl"))
+		y := Cyclo6FromSeed([]byte("circl"))
+		if x.IsEqual(y) == 0 {
+			test.ReportError(t, x, y)
+		}
+		if got := x.isInSubgroup(); got != 1 {
+			test.ReportError(t, got, 1, x)
+		}
+
+		b, _ := x.MarshalBinary()
+		got := fmt.Sprintf("%x", sha256.Sum256(b))
+		want := "f204e8d8271bd57af6a194f49e77b068976f2211e5d02d5e58862b1aa1b88a47"
+		if got != want {
+			test.ReportError(t, got, want)
+		}
+	})
+	t.Run("equalCT", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			x := randomCyclo6(t)
+			y := *x
+			if got := x.EqualCT(&y); got != 1 {
+				test.ReportError(t, got, 1, x, y)
+			}
+
+			y.Sqr(x)
+			if got := x.EqualCT(&y); got != 0 {
+				test.ReportError(t, got, 0, x, y)
+			}
+		}
+	})
+	t.Run("gob", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			in := randomCyclo6(t)
+			var buf bytes.Buffer
+			err := gob.NewEncoder(&buf).Encode(in)
+			test.CheckNoErr(t, err, "gob encoding failed")
+			stream := buf.Bytes()
+
+			var out Cyclo6
+			err = gob.NewDecoder(bytes.NewReader(stream)).Decode(&out)
+			test.CheckNoErr(t, err, "gob decoding failed")
+			if out.IsEqual(in) == 0 {
+				test.ReportError(t, out, in)
+			}
+
+			// Replace the element by one outside the cyclotomic subgroup.
+			enc, _ := in.MarshalBinary()
+			bad, _ := randomFp12(t).MarshalBinary()
+			copy(stream[bytes.Index(stream, enc):], bad)
+			err = gob.NewDecoder(bytes.NewReader(stream)).Decode(&out)
+			if !errors.Is(err, ErrNotInSubgroup) {
+				test.ReportError(t, err, ErrNotInSubgroup)
+			}
+		}
+		err := new(Cyclo6).GobDecode(make([]byte, Fp12Size-1))
+		if !errors.Is(err, ErrWrongLength) {
+			test.ReportError(t, err, ErrWrongLength)
+		}
+	})
+	t.Run("multiexp", func(t *testing.T) {
+		var got, want, t0 Cyclo6
+		for _, n := range []int{0, 1, 2, 5} {
+			bases := make([]*Cyclo6, n)
+			exps := make([][]byte, n)
+			(*Fp12)(&want).SetOne()
+			for i := range bases {
+				bases[i] = randomCyclo6(t)
+				exps[i] = make([]byte, 1+i*7)
+				_, _ = rand.Read(exps[i])
+				if i == 1 {
+					exps[i] = []byte{0, 0}
+				}
+				t0.exp(bases[i], exps[i])
+				want.Mul(&want, &t0)
+			}
+			got.MultiExp(bases, exps)
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, n, exps)
+			}
+		}
+	})
 }
 
 func BenchmarkCyclo6(b *testing.B) {
@@ -132,9 +301,48 @@ func BenchmarkCyclo6(b *testing.B) {
 			z.Inv(x)
 		}
 	})
+	b.Run("MulConj", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z.MulConj(x, y)
+		}
+	})
+	b.Run("LucasSqr", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z.LucasSqr(x)
+		}
+	})
 	b.Run("PowToX", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			z.PowToX(x)
 		}
 	})
+	b.Run("PowToX/Lucas", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z.powToX(x, true)
+		}
+	})
+
+	const numBases = 8
+	bases := make([]*Cyclo6, numBases)
+	exps := make([][]byte, numBases)
+	for i := range bases {
+		bases[i] = randomCyclo6(b)
+		exps[i] = make([]byte, ScalarSize)
+		_, _ = rand.Read(exps[i])
+	}
+	b.Run("MultiExp", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z.MultiExp(bases, exps)
+		}
+	})
+	b.Run("ExpMul", func(b *testing.B) {
+		var t Cyclo6
+		for i := 0; i < b.N; i++ {
+			(*Fp12)(z).SetOne()
+			for j := range bases {
+				t.exp(bases[j], exps[j])
+				z.Mul(z, &t)
+			}
+		}
+	})
 }
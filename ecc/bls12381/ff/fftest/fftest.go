@@ -0,0 +1,192 @@
+// Package fftest provides property tests for the field and group types of
+// package ff, such that packages embedding these types can run the same
+// conformance checks on them.
+//
+// The checks are generic over the method sets shared by the types of the
+// tower: Fp, Fp2, Fp6 and Fp12 satisfy Field, and Cyclo6 satisfies Group.
+// Elements are compared with IsEqual, so the operations under test must
+// return elements in canonical form.
+package fftest
+
+import (
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+// Group is satisfied by the pointer type *T of a multiplicative group.
+type Group[T any] interface {
+	*T
+	Mul(x, y *T)
+	Sqr(x *T)
+	Inv(x *T)
+	IsEqual(x *T) int
+}
+
+// Field is satisfied by the pointer type *T of a field.
+type Field[T any] interface {
+	Group[T]
+	Add(x, y *T)
+	Sub(x, y *T)
+	SetOne()
+	IsZero() int
+}
+
+// Times is the number of random inputs used by each property test.
+var Times = 1 << 9
+
+// TestGroupAxioms checks associativity and commutativity of Mul, that Sqr
+// and Inv agree with Mul, and that the operations support aliasing of their
+// inputs and output. newRandom must return a random element of the group.
+func TestGroupAxioms[T any, PT Group[T]](t *testing.T, newRandom func() *T) {
+	t.Run("no_alias", func(t *testing.T) {
+		for i := 0; i < Times; i++ {
+			x, y := newRandom(), newRandom()
+			var want, got T
+			PT(&want).Mul(x, y)
+			got = *x
+			PT(&got).Mul(&got, y)
+			if PT(&got).IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x, y)
+			}
+			got = *y
+			PT(&got).Mul(x, &got)
+			if PT(&got).IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x, y)
+			}
+
+			PT(&want).Sqr(x)
+			got = *x
+			PT(&got).Sqr(&got)
+			if PT(&got).IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x)
+			}
+
+			PT(&want).Inv(x)
+			got = *x
+			PT(&got).Inv(&got)
+			if PT(&got).IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x)
+			}
+		}
+	})
+	t.Run("mul_comm", func(t *testing.T) {
+		for i := 0; i < Times; i++ {
+			x, y := newRandom(), newRandom()
+			var l, r T
+			PT(&l).Mul(x, y)
+			PT(&r).Mul(y, x)
+			if PT(&l).IsEqual(&r) == 0 {
+				test.ReportError(t, l, r, x, y)
+			}
+		}
+	})
+	t.Run("mul_assoc", func(t *testing.T) {
+		for i := 0; i < Times; i++ {
+			x, y, z := newRandom(), newRandom(), newRandom()
+			var l, r T
+			// (x*y)*z = x*(y*z)
+			PT(&l).Mul(x, y)
+			PT(&l).Mul(&l, z)
+			PT(&r).Mul(y, z)
+			PT(&r).Mul(x, &r)
+			if PT(&l).IsEqual(&r) == 0 {
+				test.ReportError(t, l, r, x, y, z)
+			}
+		}
+	})
+	t.Run("mul_sqr", func(t *testing.T) {
+		for i := 0; i < Times; i++ {
+			x := newRandom()
+			var got, want T
+			PT(&got).Mul(x, x)
+			PT(&want).Sqr(x)
+			if PT(&got).IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x)
+			}
+		}
+	})
+	t.Run("mul_inv", func(t *testing.T) {
+		for i := 0; i < Times; i++ {
+			x, y := newRandom(), newRandom()
+			var got, invX T
+			// (x*y)*x^-1 = y
+			PT(&invX).Inv(x)
+			PT(&got).Mul(x, y)
+			PT(&got).Mul(&got, &invX)
+			if PT(&got).IsEqual(y) == 0 {
+				test.ReportError(t, got, *y, x, y)
+			}
+		}
+	})
+}
+
+// TestFieldAxioms runs TestGroupAxioms and additionally checks the additive
+// group, distributivity, and that x*x^-1 = 1 for x != 0. newRandom must
+// return a random element of the field.
+func TestFieldAxioms[T any, PT Field[T]](t *testing.T, newRandom func() *T) {
+	TestGroupAxioms[T, PT](t, newRandom)
+	t.Run("add_assoc_comm", func(t *testing.T) {
+		for i := 0; i < Times; i++ {
+			x, y, z := newRandom(), newRandom(), newRandom()
+			var l, r T
+			// (x+y)+z = (z+y)+x
+			PT(&l).Add(x, y)
+			PT(&l).Add(&l, z)
+			PT(&r).Add(z, y)
+			PT(&r).Add(&r, x)
+			if PT(&l).IsEqual(&r) == 0 {
+				test.ReportError(t, l, r, x, y, z)
+			}
+		}
+	})
+	t.Run("add_sub", func(t *testing.T) {
+		for i := 0; i < Times; i++ {
+			x, y := newRandom(), newRandom()
+			var got, zero T
+			// (x+y)-y = x
+			PT(&got).Add(x, y)
+			PT(&got).Sub(&got, y)
+			if PT(&got).IsEqual(x) == 0 {
+				test.ReportError(t, got, *x, x, y)
+			}
+			// x-x = 0
+			PT(&zero).Sub(x, x)
+			if PT(&zero).IsZero() == 0 {
+				test.ReportError(t, zero, 0, x)
+			}
+		}
+	})
+	t.Run("distributivity", func(t *testing.T) {
+		for i := 0; i < Times; i++ {
+			x, y, z := newRandom(), newRandom(), newRandom()
+			var l, r, t0 T
+			// x*(y+z) = x*y + x*z
+			PT(&l).Add(y, z)
+			PT(&l).Mul(x, &l)
+			PT(&r).Mul(x, y)
+			PT(&t0).Mul(x, z)
+			PT(&r).Add(&r, &t0)
+			if PT(&l).IsEqual(&r) == 0 {
+				test.ReportError(t, l, r, x, y, z)
+			}
+		}
+	})
+	t.Run("inv", func(t *testing.T) {
+		var one T
+		PT(&one).SetOne()
+		for i := 0; i < Times; i++ {
+			x := newRandom()
+			if PT(x).IsZero() == 1 {
+				continue
+			}
+			var got T
+			// x*x^-1 = 1
+			PT(&got).Inv(x)
+			PT(&got).Mul(&got, x)
+			if PT(&got).IsEqual(&one) == 0 {
+				test.ReportError(t, got, one, x)
+			}
+		}
+	})
+}
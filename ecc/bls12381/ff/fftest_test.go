@@ -0,0 +1,15 @@
+package ff
+
+import (
+	"testing"
+
+	"github.com/cloudflare/circl/ecc/bls12381/ff/fftest"
+)
+
+func TestFieldAxioms(t *testing.T) {
+	t.Run("Fp", func(t *testing.T) { fftest.TestFieldAxioms(t, func() *Fp { return randomFp(t) }) })
+	t.Run("Fp2", func(t *testing.T) { fftest.TestFieldAxioms(t, func() *Fp2 { return randomFp2(t) }) })
+	t.Run("Fp6", func(t *testing.T) { fftest.TestFieldAxioms(t, func() *Fp6 { return randomFp6(t) }) })
+	t.Run("Fp12", func(t *testing.T) { fftest.TestFieldAxioms(t, func() *Fp12 { return randomFp12(t) }) })
+	t.Run("Cyclo6", func(t *testing.T) { fftest.TestGroupAxioms(t, func() *Cyclo6 { return randomCyclo6(t) }) })
+}
@@ -2,6 +2,7 @@ package ff
 
 import (
 	"io"
+	"math/bits"
 
 	"github.com/cloudflare/circl/internal/conv"
 )
@@ -45,6 +46,56 @@ func (z *Fp) toMont(in *fpRaw)     { fiatFpMontMul(&z.i, in, &fpRSquare) }
 func (z Fp) fromMont() (out fpRaw) { fiatFpMontMul(&out, &z.i, &fpMont{1}); return }
 func (z Fp) Sgn0() int             { return int(z.fromMont()[0]) & 1 }
 
+// Double calculates z=2z.
+func (z *Fp) Double() { z.Add(z, z) }
+
+// Halve calculates z=z/2. Since the Montgomery form of z/2 is half of the
+// one of z, it adds FpOrder to z if it is odd, and shifts the sum to the
+// right, which is cheaper than multiplying by the inverse of two.
+func (z *Fp) Halve() {
+	var t fpRaw
+	var c uint64
+	mask := -(z.i[0] & 1)
+	for i := range t {
+		t[i], c = bits.Add64(z.i[i], fpOrderWords[i]&mask, c)
+	}
+	for i := 0; i < len(t)-1; i++ {
+		z.i[i] = t[i]>>1 | t[i+1]<<63
+	}
+	z.i[len(t)-1] = t[len(t)-1]>>1 | c<<63
+}
+
+// Normalize reduces the internal representation of z to its canonical form,
+// i.e., a residue in the range [0, FpOrder). All operations of this package
+// return elements in canonical form, so this is only needed for values
+// assembled by other means.
+func (z *Fp) Normalize() { fiatFpMontMul(&z.i, &z.i, &fpROne) }
+
+// IsCanonical returns 1 if the internal representation of z is in canonical
+// form, i.e., a residue in the range [0, FpOrder), and 0 otherwise. It runs
+// in constant time, and is meant for validating elements assembled by other
+// means than the decoding functions, which reject non-canonical inputs. See
+// Normalize for reducing z to its canonical form.
+func (z *Fp) IsCanonical() int {
+	var b uint64
+	for i := range z.i {
+		_, b = bits.Sub64(z.i[i], fpOrderWords[i], b)
+	}
+	return int(b)
+}
+
+// Cmp returns -1, 0 or +1 depending on whether z is less than, equal to, or
+// greater than x, as integers in [0, FpOrder). It runs in constant time.
+func (z Fp) Cmp(x *Fp) int {
+	a, b := z.fromMont(), x.fromMont()
+	var lt, gt uint64
+	for i := range a {
+		_, lt = bits.Sub64(a[i], b[i], lt)
+		_, gt = bits.Sub64(b[i], a[i], gt)
+	}
+	return int(gt) - int(lt)
+}
+
 // Sqrt returns 1 and sets z=sqrt(x) only if x is a quadratic-residue; otherwise, returns 0 and z is unmodified.
 func (z *Fp) Sqrt(x *Fp) int {
 	var y, y2 Fp
@@ -92,6 +143,57 @@ func (z *Fp) SetBytes(data []byte) {
 	z.toMont(s)
 }
 
+// FpUniformSize is the minimum length in bytes of the input to
+// Fp.SetUniformBytes.
+const FpUniformSize = 64
+
+// SetUniformBytes assigns to z the number stored in the slice (in big-endian
+// order) reduced modulo FpOrder. If b is the encoding of a uniformly random
+// number of n=8*len(b) bits, the statistical distance between z and a
+// uniformly random element of Fp is at most FpOrder/2^n < 2^(381-n). Hence,
+// for FpUniformSize bytes or more, the bias is less than 2^-131. Its running
+// time depends only on the length of b. It panics if b is shorter than
+// FpUniformSize bytes.
+func (z *Fp) SetUniformBytes(b []byte) {
+	if len(b) < FpUniformSize {
+		panic("ff: input to SetUniformBytes is too short")
+	}
+	// Horner's rule in base 2^256, whose digits are already reduced modulo
+	// FpOrder.
+	const digitSize = 32
+	var base, digit Fp
+	base.toMont(&fpRaw{0, 0, 0, 0, 1})
+	*z = Fp{}
+	for len(b) > 0 {
+		n := len(b) % digitSize
+		if n == 0 {
+			n = digitSize
+		}
+		var d [digitSize]byte
+		copy(d[digitSize-n:], b[:n])
+		raw := &fpRaw{}
+		copy(raw[:], conv.BytesBe2Uint64Le(d[:]))
+		digit.toMont(raw)
+		z.Mul(z, &base)
+		z.Add(z, &digit)
+		b = b[n:]
+	}
+}
+
+// RandomFpFast returns a uniformly random element of Fp, up to a negligible
+// bias, by reducing FpUniformSize random bytes with Fp.SetUniformBytes.
+// Unlike Fp.Random, it reads a fixed number of bytes from r, and it runs in
+// constant time.
+func RandomFpFast(r io.Reader) (*Fp, error) {
+	var b [FpUniformSize]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return nil, err
+	}
+	z := new(Fp)
+	z.SetUniformBytes(b[:])
+	return z, nil
+}
+
 // MarshalBinary returns a slice of FpSize bytes that contains the minimal
 // residue of z such that 0 <= z < FpOrder (in big-endian order).
 func (z *Fp) MarshalBinary() ([]byte, error) {
@@ -104,7 +206,7 @@ func (z *Fp) MarshalBinary() ([]byte, error) {
 // to FpOrder-1.
 func (z *Fp) UnmarshalBinary(b []byte) error {
 	if len(b) < FpSize {
-		return errInputLength
+		return decodeError("Fp", ErrWrongLength)
 	}
 	in64, err := setBytesBounded(b[:FpSize], fpOrder[:])
 	if err == nil {
@@ -112,7 +214,7 @@ func (z *Fp) UnmarshalBinary(b []byte) error {
 		copy(s[:], in64[:FpSize/8])
 		z.toMont(s)
 	}
-	return err
+	return decodeError("Fp", err)
 }
 
 // SetString reconstructs a Fp from a numeric string from 0 to FpOrder-1.
@@ -126,9 +228,25 @@ func (z *Fp) SetString(s string) error {
 	return err
 }
 
+// InvSafeGCD calculates z=1/x (or z=0 if x=0) using the constant-time
+// safegcd algorithm of Bernstein and Yang.
+func (z *Fp) InvSafeGCD(x *Fp) {
+	var s signed62
+	var y fpRaw
+	s.fromUint64Le(x.i[:])
+	fpModInfo.inv(&s)
+	s.toUint64Le(y[:])
+	// Since x is stored as aR, then y = 1/(aR), and z = y*R^3/R = (1/a)R.
+	fiatFpMontMul(&z.i, &y, &fpRCube)
+}
+
 func fiatFpMontCmovznzU64(z *uint64, b, x, y uint64) { cselectU64(z, b, x, y) }
 
-func (z *Fp) Inv(x *Fp) {
+// Inv calculates z=1/x (or z=0 if x=0) in constant time.
+func (z *Fp) Inv(x *Fp) { z.InvSafeGCD(x) }
+
+// invFermat calculates z=x^(p-2) using an addition chain.
+func (z *Fp) invFermat(x *Fp) {
 	// Addition chain found using mmcloughlin/addchain: v0.3.0
 	// McLoughlin, Michael Ben. (2021). https://doi.org/10.5281/zenodo.4758226
 	var i2, i4, i8, i9, i11, i13, i17, i20, i25, i26, i52, i54, i55, i77, i79,
@@ -236,6 +354,11 @@ var (
 		0x1e, 0xab, 0xff, 0xfe, 0xb1, 0x53, 0xff, 0xff,
 		0xb9, 0xfe, 0xff, 0xff, 0xff, 0xff, 0xaa, 0xab,
 	}
+	// fpOrderWords is fpOrder (little-endian).
+	fpOrderWords = fpRaw{
+		0xb9feffffffffaaab, 0x1eabfffeb153ffff, 0x6730d2a0f6b0f624,
+		0x64774b84f38512bf, 0x4b1ba7b6434bacd7, 0x1a0111ea397fe69a,
+	}
 	// fpOrderPlus1Div2 is the half of (fpOrder plus one) used for lexicographically order (big-endian).
 	fpOrderPlus1Div2 = [FpSize]byte{
 		0x0d, 0x00, 0x88, 0xf5, 0x1c, 0xbf, 0xf3, 0x4d,
@@ -254,10 +377,22 @@ var (
 		0x07, 0xaa, 0xff, 0xff, 0xac, 0x54, 0xff, 0xff,
 		0xee, 0x7f, 0xbf, 0xff, 0xff, 0xff, 0xea, 0xab,
 	}
+	// fpROne is R mod fpOrder, where R=2^384 (little-endian).
+	fpROne = fpMont{
+		0x760900000002fffd, 0xebf4000bc40c0002,
+		0x5f48985753c758ba, 0x77ce585370525745,
+		0x5c071a97a256ec6d, 0x15f65ec3fa80e493,
+	}
 	// fpRSquare is R^2 mod fpOrder, where R=2^384 (little-endian).
 	fpRSquare = fpMont{
 		0xf4df1f341c341746, 0x0a76e6a609d104f1,
 		0x8de5476c4c95b6d5, 0x67eb88a9939d83c0,
 		0x9a793e85b519952d, 0x11988fe592cae3aa,
 	}
+	// fpRCube is R^3 mod fpOrder, where R=2^384 (little-endian).
+	fpRCube = fpMont{
+		0xed48ac6bd94ca1e0, 0x315f831e03a7adf8,
+		0x9a53352a615e29dd, 0x34c04e5e921e1761,
+		0x2512d43565724728, 0x0aa6346091755d4d,
+	}
 )
@@ -3,12 +3,22 @@ package ff
 import (
 	"crypto/subtle"
 	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/cloudflare/circl/internal/sha3"
+	"github.com/cloudflare/circl/math"
 )
 
 // Fp12Size is the length in bytes of an Fp12 element.
 const Fp12Size = 2 * Fp6Size
 
 // Fp12 represents an element of the field Fp12 = Fp6[w]/(w^2-v)., where v in Fp6.
+//
+// Every operation of this package returns elements whose Fp coordinates are
+// in canonical form, i.e., fully reduced. IsEqual compares representations,
+// so elements assembled by other means must be normalized with Normalize
+// before being compared.
 type Fp12 [2]Fp6
 
 func (z Fp12) String() string      { return fmt.Sprintf("0: %v\n1: %v", z[0], z[1]) }
@@ -18,9 +28,14 @@ func (z Fp12) IsEqual(x *Fp12) int { return z[0].IsEqual(&x[0]) & z[1].IsEqual(&
 func (z *Fp12) MulBeta()           { t := z[0]; z[0].Sub(&z[0], &z[1]); z[1].Add(&t, &z[1]) }
 func (z *Fp12) Frob(x *Fp12)       { z[0].Frob(&x[0]); z[1].Frob(&x[1]); z[1].Mul(&z[1], &Fp6{frob12W1}) }
 func (z *Fp12) Cjg()               { z[1].Neg() }
-func (z *Fp12) Neg()               { z[0].Neg(); z[1].Neg() }
-func (z *Fp12) Add(x, y *Fp12)     { z[0].Add(&x[0], &y[0]); z[1].Add(&x[1], &y[1]) }
-func (z *Fp12) Sub(x, y *Fp12)     { z[0].Sub(&x[0], &y[0]); z[1].Sub(&x[1], &y[1]) }
+
+// Conjugate calculates z=x^(p^6), which only negates the coefficient of w.
+func (z *Fp12) Conjugate(x *Fp12) { *z = *x; z.Cjg() }
+
+func (z *Fp12) Normalize()     { z[0].Normalize(); z[1].Normalize() }
+func (z *Fp12) Neg()           { z[0].Neg(); z[1].Neg() }
+func (z *Fp12) Add(x, y *Fp12) { z[0].Add(&x[0], &y[0]); z[1].Add(&x[1], &y[1]) }
+func (z *Fp12) Sub(x, y *Fp12) { z[0].Sub(&x[0], &y[0]); z[1].Sub(&x[1], &y[1]) }
 func (z *Fp12) Mul(x, y *Fp12) {
 	var x0y0, x1y1, sx, sy, k Fp6
 	x0y0.Mul(&x[0], &y[0])
@@ -34,6 +49,25 @@ func (z *Fp12) Mul(x, y *Fp12) {
 	z[0].Add(&x0y0, &x1y1)
 }
 
+// MulBy014 calculates z=x*y for the sparse element
+// y = (c0 + c1*v) + (c4*v)*w, whose non-zero coefficients are the 0-th, 1-st
+// and 4-th ones over Fp2. For instance, the value l[0] + l[1]*w^2 + l[2]*w^3
+// of a LineValue l is such an element, with c0=l[0], c1=l[1] and c4=l[2]. It
+// uses 13 multiplications in Fp2 instead of 18.
+func (z *Fp12) MulBy014(x *Fp12, c0, c1, c4 *Fp2) {
+	var x0y0, x1y1, k Fp6
+	var c14 Fp2
+	x0y0.MulBy01(&x[0], c0, c1)
+	x1y1.MulBy1(&x[1], c4)
+	c14.Add(c1, c4)
+	k.Add(&x[0], &x[1])
+	k.MulBy01(&k, c0, &c14)
+	z[1].Sub(&k, &x0y0)
+	z[1].Sub(&z[1], &x1y1)
+	x1y1.MulBeta()
+	z[0].Add(&x0y0, &x1y1)
+}
+
 func (z *Fp12) Sqr(x *Fp12) {
 	var x02, x12, k Fp6
 	x02.Sqr(&x[0])
@@ -61,7 +95,32 @@ func (z *Fp12) CMov(x, y *Fp12, b int) {
 	z[1].CMov(&x[1], &y[1], b)
 }
 
-// Exp calculates z=x^n, where n is the exponent in big-endian order.
+// SetRandomNonZero sets z to a uniformly random non-zero element of Fp12,
+// which is thus invertible, using r as the source of randomness. Zero is
+// rejected and sampled again. On error, z is not modified.
+func (z *Fp12) SetRandomNonZero(r io.Reader) error {
+	var x Fp12
+	for {
+		for i := range x {
+			for j := range x[i] {
+				for k := range x[i][j] {
+					if err := x[i][j][k].Random(r); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		if x.IsZero() == 0 {
+			*z = x
+			return nil
+		}
+	}
+}
+
+// Exp calculates z=x^n, where n is the exponent in big-endian order. It uses
+// a fixed window of 4 bits over the whole length of n, so its running time
+// only depends on len(n), and n can be secret. See ExpVarTime for public
+// exponents.
 func (z *Fp12) Exp(x *Fp12, n []byte) {
 	zz := new(Fp12)
 	zz.SetOne()
@@ -89,9 +148,45 @@ func (z *Fp12) Exp(x *Fp12, n []byte) {
 	*z = *zz
 }
 
+// expVarTimeOmega is the window size of the wNAF exponents of ExpVarTime.
+const expVarTimeOmega = 5
+
+// ExpVarTime calculates z=x^n, where n is the exponent in big-endian order.
+// The exponent is recoded in wNAF form, and since inversion is not free in
+// Fp12, the inverses of the odd powers of x are precomputed for the negative
+// digits. It runs in variable time, so n must be public.
+func (z *Fp12) ExpVarTime(x *Fp12, n []byte) {
+	const tableSize = 1 << (expVarTimeOmega - 2)
+	var pos, neg [tableSize]Fp12
+	var x2, xInv Fp12
+	x2.Sqr(x)
+	pos[0] = *x
+	for i := 1; i < tableSize; i++ {
+		pos[i].Mul(&pos[i-1], &x2)
+	}
+	xInv.Inv(&pos[tableSize-1])
+	neg[tableSize-1] = xInv
+	for i := tableSize - 2; i >= 0; i-- {
+		neg[i].Mul(&neg[i+1], &x2)
+	}
+
+	L := math.OmegaNAF(new(big.Int).SetBytes(n), expVarTimeOmega)
+	var zz Fp12
+	zz.SetOne()
+	for i := len(L) - 1; i >= 0; i-- {
+		zz.Sqr(&zz)
+		if d := L[i]; d > 0 {
+			zz.Mul(&zz, &pos[d>>1])
+		} else if d < 0 {
+			zz.Mul(&zz, &neg[(-d)>>1])
+		}
+	}
+	*z = zz
+}
+
 func (z *Fp12) UnmarshalBinary(b []byte) error {
 	if len(b) < Fp12Size {
-		return errInputLength
+		return decodeError("Fp12", ErrWrongLength)
 	}
 	return errFirst(
 		z[1].UnmarshalBinary(b[:Fp6Size]),
@@ -99,6 +194,11 @@ func (z *Fp12) UnmarshalBinary(b []byte) error {
 	)
 }
 
+// Cmp returns -1, 0 or +1 depending on whether z is less than, equal to, or
+// greater than x in lexicographic order, where z[1] is compared first. This is
+// the order of the encodings given by MarshalBinary.
+func (z Fp12) Cmp(x *Fp12) int { return cmpLex(z[1].Cmp(&x[1]), z[0].Cmp(&x[0])) }
+
 func (z Fp12) MarshalBinary() (b []byte, e error) {
 	var b0, b1 []byte
 	if b1, e = z[1].MarshalBinary(); e == nil {
@@ -109,6 +209,83 @@ func (z Fp12) MarshalBinary() (b []byte, e error) {
 	return
 }
 
+// FrobeniusPowers sets out[i] = z^(p^i) for i = 0, ..., len(out)-1. Each
+// power is obtained from the previous one with a single Frobenius map,
+// instead of an exponentiation. The pointers in out must be distinct, but z
+// may be one of them.
+func (z *Fp12) FrobeniusPowers(out []*Fp12) {
+	if len(out) == 0 {
+		return
+	}
+	*out[0] = *z
+	for i := 1; i < len(out); i++ {
+		out[i].Frob(out[i-1])
+	}
+}
+
+// InCyclotomic returns 1 if z belongs to the 6-th cyclotomic group, i.e.,
+// z^(p^4-p^2+1) = 1, as the outputs of EasyExponentiation do; otherwise, it
+// returns 0. The test is done with Frobenius maps, which is much cheaper
+// than exponentiating. Note that it does not check that z has order r; see
+// Cyclo6 for the group of order p^4-p^2+1.
+func (z *Fp12) InCyclotomic() int { return (*Cyclo6)(z).isInSubgroup() }
+
+// GobEncode implements gob.GobEncoder, returning the encoding of
+// MarshalBinary.
+func (z *Fp12) GobEncode() ([]byte, error) { return z.MarshalBinary() }
+
+// GobDecode implements gob.GobDecoder. It is as UnmarshalBinary, except that
+// b must have exactly Fp12Size bytes, and z is not modified on error.
+func (z *Fp12) GobDecode(b []byte) error {
+	if len(b) != Fp12Size {
+		return decodeError("Fp12", ErrWrongLength)
+	}
+	var x Fp12
+	if err := x.UnmarshalBinary(b); err != nil {
+		return err
+	}
+	*z = x
+	return nil
+}
+
+// EqualCT returns 1 if z and x are equal, and 0 otherwise. It compares the
+// canonical encodings of the elements with subtle.ConstantTimeCompare, so,
+// unlike IsEqual, the elements need not be normalized. Its running time does
+// not depend on which coordinates differ, so it should be preferred when the
+// elements are derived from secrets, e.g., when checking a pairing value
+// computed from a secret point. Verifying public values, such as BLS
+// signatures, can use IsEqual.
+func (z *Fp12) EqualCT(x *Fp12) int {
+	a, errA := z.MarshalBinary()
+	b, errB := x.MarshalBinary()
+	if errA != nil || errB != nil {
+		return 0
+	}
+	return subtle.ConstantTimeCompare(a, b)
+}
+
+// Fp12FromSeed returns an element of Fp12 derived deterministically from
+// the seed, so that the same seed yields the same element on all platforms.
+// Each of the twelve coordinates in Fp is obtained by reducing
+// FpUniformSize bytes of the output of SHAKE-256 on the seed, as in
+// Fp.SetUniformBytes. It is meant for reproducible test vectors and
+// benchmarks, and not as a hash to Fp12.
+func Fp12FromSeed(seed []byte) *Fp12 {
+	var b [FpUniformSize]byte
+	h := sha3.NewShake256()
+	_, _ = h.Write(seed)
+	z := new(Fp12)
+	for i := range z {
+		for j := range z[i] {
+			for k := range z[i][j] {
+				_, _ = h.Read(b[:])
+				z[i][j][k].SetUniformBytes(b[:])
+			}
+		}
+	}
+	return z
+}
+
 // frob12W1 is Fp2 = [toMont(frob12W1_0), toMont(frob12W1_1) ], where
 //
 //	frob12W1_0 = 0x1904d3bf02bb0667c231beb4202c0d1f0fd603fd3cbd5f4f7b2443d784bab9c4f67ea53d63e7813d8d0775ed92235fb8
@@ -1,6 +1,13 @@
 package ff
 
 import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/cloudflare/circl/internal/test"
@@ -8,6 +15,16 @@ import (
 
 func randomFp12(t testing.TB) *Fp12 { return &Fp12{*randomFp6(t), *randomFp6(t)} }
 
+func randomNonZeroFp12(t testing.TB) *Fp12 {
+	t.Helper()
+	z := new(Fp12)
+	err := z.SetRandomNonZero(rand.Reader)
+	if err != nil {
+		t.Error(err)
+	}
+	return z
+}
+
 func TestFp12(t *testing.T) {
 	const testTimes = 1 << 8
 	t.Run("no_alias", func(t *testing.T) {
@@ -21,10 +38,38 @@ func TestFp12(t *testing.T) {
 			test.ReportError(t, got, want, x)
 		}
 	})
+	t.Run("mul_by_014", func(t *testing.T) {
+		var want, got Fp12
+		var xc, lc Fp12Cubic
+		for i := 0; i < testTimes; i++ {
+			x := randomFp12(t)
+			l := LineValue{*randomFp2(t), *randomFp2(t), *randomFp2(t)}
+
+			// The line value l[0] + l[1]*w^2 + l[2]*w^3 is sparse in Fp12.
+			got.MulBy014(x, &l[0], &l[1], &l[2])
+			want.Mul(x, &Fp12{Fp6{l[0], l[1], Fp2{}}, Fp6{Fp2{}, l[2], Fp2{}}})
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x, l)
+			}
+			got = *x
+			got.MulBy014(&got, &l[0], &l[1], &l[2])
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x, l, "aliased")
+			}
+
+			// Same as the product by a line value in Fp12Cubic.
+			xc.FromFp12(x)
+			lc.MulLine(&xc, &l)
+			want.FromFp12Cubic(&lc)
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x, l)
+			}
+		}
+	})
 	t.Run("mul_inv", func(t *testing.T) {
 		var z Fp12
 		for i := 0; i < testTimes; i++ {
-			x := randomFp12(t)
+			x := randomNonZeroFp12(t)
 			y := randomFp12(t)
 
 			// x*y*x^1 - y = 0
@@ -39,6 +84,30 @@ func TestFp12(t *testing.T) {
 			}
 		}
 	})
+	t.Run("random_non_zero", func(t *testing.T) {
+		var inv, one, got Fp12
+		one.SetOne()
+		for i := 0; i < testTimes; i++ {
+			x := randomNonZeroFp12(t)
+			if x.IsZero() == 1 {
+				test.ReportError(t, x, "non-zero element")
+			}
+
+			// x*x^-1 = 1
+			inv.Inv(x)
+			got.Mul(x, &inv)
+			if got.IsEqual(&one) == 0 {
+				test.ReportError(t, got, one, x)
+			}
+		}
+
+		var z Fp12
+		err := z.SetRandomNonZero(bytes.NewReader(nil))
+		test.CheckIsErr(t, err, "SetRandomNonZero should fail on short reads")
+		if z.IsZero() == 0 {
+			test.ReportError(t, z, Fp12{})
+		}
+	})
 	t.Run("mul_sqr", func(t *testing.T) {
 		var l0, l1, r0, r1 Fp12
 		for i := 0; i < testTimes; i++ {
@@ -59,6 +128,27 @@ func TestFp12(t *testing.T) {
 			}
 		}
 	})
+	t.Run("tower", func(t *testing.T) {
+		var got, want Fp12
+		var ac, bd, ad, bc Fp6
+		for i := 0; i < testTimes; i++ {
+			a, b := randomFp6(t), randomFp6(t)
+			c, d := randomFp6(t), randomFp6(t)
+
+			// (a + b*w)*(c + d*w) = (ac + bd*v) + (ad + bc)*w
+			got.Mul(&Fp12{*a, *b}, &Fp12{*c, *d})
+			ac.Mul(a, c)
+			bd.Mul(b, d)
+			bd.MulByNonResidue(&bd)
+			ad.Mul(a, d)
+			bc.Mul(b, c)
+			want[0].Add(&ac, &bd)
+			want[1].Add(&ad, &bc)
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, a, b, c, d)
+			}
+		}
+	})
 	t.Run("marshal", func(t *testing.T) {
 		var b Fp12
 		for i := 0; i < testTimes; i++ {
@@ -72,6 +162,130 @@ func TestFp12(t *testing.T) {
 			}
 		}
 	})
+	t.Run("cmp", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			x := randomFp12(t)
+			y0, y1 := *randomFp12(t), *x
+			y1[0] = *randomFp6(t)
+			for _, y := range []*Fp12{&y0, &y1, x} {
+				bx, _ := x.MarshalBinary()
+				by, _ := y.MarshalBinary()
+				if got, want := x.Cmp(y), bytes.Compare(bx, by); got != want {
+					test.ReportError(t, got, want, x, y)
+				}
+			}
+		}
+	})
+	t.Run("gob", func(t *testing.T) {
+		type message struct{ X, Y *Fp12 }
+		for i := 0; i < testTimes; i++ {
+			in := message{randomFp12(t), randomFp12(t)}
+			var buf bytes.Buffer
+			err := gob.NewEncoder(&buf).Encode(in)
+			test.CheckNoErr(t, err, "gob encoding failed")
+			stream := buf.Bytes()
+
+			var out message
+			err = gob.NewDecoder(bytes.NewReader(stream)).Decode(&out)
+			test.CheckNoErr(t, err, "gob decoding failed")
+			if out.X.IsEqual(in.X) == 0 || out.Y.IsEqual(in.Y) == 0 {
+				test.ReportError(t, out, in)
+			}
+
+			// Make the leading coordinate of Y not canonical.
+			enc, _ := in.Y.MarshalBinary()
+			stream[bytes.Index(stream, enc)] = 0xFF
+			err = gob.NewDecoder(bytes.NewReader(stream)).Decode(&out)
+			if !errors.Is(err, ErrFieldNotCanonical) {
+				test.ReportError(t, err, ErrFieldNotCanonical)
+			}
+		}
+		err := new(Fp12).GobDecode(make([]byte, Fp12Size+1))
+		if !errors.Is(err, ErrWrongLength) {
+			test.ReportError(t, err, ErrWrongLength)
+		}
+	})
+	t.Run("in_cyclotomic", func(t *testing.T) {
+		var c Cyclo6
+		for i := 0; i < testTimes; i++ {
+			x := randomFp12(t)
+			if got := x.InCyclotomic(); got != 0 {
+				test.ReportError(t, got, 0, x)
+			}
+			EasyExponentiation(&c, x)
+			if got := (*Fp12)(&c).InCyclotomic(); got != 1 {
+				test.ReportError(t, got, 1, x)
+			}
+		}
+		var zero, one Fp12
+		one.SetOne()
+		if got := zero.InCyclotomic(); got != 0 {
+			test.ReportError(t, got, 0)
+		}
+		if got := one.InCyclotomic(); got != 1 {
+			test.ReportError(t, got, 1)
+		}
+	})
+	t.Run("normalize", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			x := randomFp12(t)
+			y := *x
+			// y is x with one coordinate not reduced modulo p.
+			unreduceFp(&y[1][2][0])
+
+			if y.IsEqual(x) != 0 {
+				test.ReportError(t, y, x)
+			}
+			y.Normalize()
+			if y.IsEqual(x) == 0 {
+				test.ReportError(t, y, x)
+			}
+
+			c := randomCyclo6(t)
+			d := *c
+			unreduceFp(&d[0][1][1])
+			d.Normalize()
+			if d.IsEqual(c) == 0 {
+				test.ReportError(t, d, c)
+			}
+		}
+	})
+	t.Run("from_seed", func(t *testing.T) {
+		x := Fp12FromSeed([]byte("circl"))
+		y := Fp12FromSeed([]byte("circl"))
+		if x.IsEqual(y) == 0 {
+			test.ReportError(t, x, y)
+		}
+		z := Fp12FromSeed([]byte("circl2"))
+		if x.IsEqual(z) == 1 {
+			test.ReportError(t, x, z)
+		}
+
+		// Known answer, so that the output stays the same across platforms.
+		b, _ := x.MarshalBinary()
+		got := fmt.Sprintf("%x", sha256.Sum256(b))
+		want := "55d569dae48d9bd42bda575bd22f08d042b18b9dbfdeb02572d2eb1a38869858"
+		if got != want {
+			test.ReportError(t, got, want)
+		}
+	})
+	t.Run("equalCT", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			x := randomFp12(t)
+			y := *x
+			// y is x with one coordinate not reduced modulo p.
+			unreduceFp(&y[0][1][1])
+			if got := x.EqualCT(&y); got != 1 {
+				test.ReportError(t, got, 1, x, y)
+			}
+
+			y[1][2][0].SetOne()
+			y[1][2][0].Add(&y[1][2][0], &x[1][2][0])
+			if got := x.EqualCT(&y); got != 0 {
+				test.ReportError(t, got, 0, x, y)
+			}
+		}
+	})
 	t.Run("frobenius", func(t *testing.T) {
 		var got, want Fp12
 		p := FpOrder()
@@ -87,6 +301,44 @@ func TestFp12(t *testing.T) {
 			}
 		}
 	})
+	t.Run("conjugate", func(t *testing.T) {
+		var got, want, n Fp12
+		p := new(big.Int).SetBytes(FpOrder())
+		p6 := new(big.Int).Exp(p, big.NewInt(6), nil).Bytes()
+		for i := 0; i < testTimes/16; i++ {
+			x := randomFp12(t)
+
+			// Conjugate(x) == x^(p^6)
+			got.Conjugate(x)
+			want.Exp(x, p6)
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x)
+			}
+
+			// x*Conjugate(x) is in Fp6.
+			n.Mul(x, &got)
+			if n[1].IsZero() == 0 {
+				test.ReportError(t, n, "element of Fp6", x)
+			}
+		}
+	})
+	t.Run("exp", func(t *testing.T) {
+		var got, want Fp12
+		for i := 0; i < testTimes/4; i++ {
+			x := randomFp12(t)
+			n := make([]byte, 1+i%48)
+			_, _ = rand.Read(n)
+			if i == 0 {
+				n = n[:0]
+			}
+
+			got.ExpVarTime(x, n)
+			want.Exp(x, n)
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x, n)
+			}
+		}
+	})
 }
 
 func BenchmarkFp12(b *testing.B) {
@@ -104,6 +356,19 @@ func BenchmarkFp12(b *testing.B) {
 			z.Mul(x, y)
 		}
 	})
+	b.Run("MulBy014", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z.MulBy014(x, &y[0][0], &y[0][1], &y[1][1])
+		}
+	})
+	b.Run("MulLineCubic", func(b *testing.B) {
+		var zc Fp12Cubic
+		zc.FromFp12(z)
+		l := LineValue{y[0][0], y[0][1], y[1][1]}
+		for i := 0; i < b.N; i++ {
+			zc.MulLine(&zc, &l)
+		}
+	})
 	b.Run("Sqr", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			z.Sqr(x)
@@ -114,4 +379,15 @@ func BenchmarkFp12(b *testing.B) {
 			z.Inv(x)
 		}
 	})
+	n := ScalarOrder()
+	b.Run("Exp", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z.Exp(x, n)
+		}
+	})
+	b.Run("ExpVarTime", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z.ExpVarTime(x, n)
+		}
+	})
 }
@@ -1,6 +1,10 @@
 package ff
 
-import "fmt"
+import (
+	"crypto/subtle"
+	"fmt"
+	"math/bits"
+)
 
 // Fp2Size is the length in bytes of an Fp2 element.
 const Fp2Size = 2 * FpSize
@@ -17,6 +21,7 @@ func (z Fp2) IsEqual(x *Fp2) int { return z[0].IsEqual(&x[0]) & z[1].IsEqual(&x[
 func (z *Fp2) MulBeta()          { t := z[0]; z[0].Sub(&z[0], &z[1]); z[1].Add(&t, &z[1]) }
 func (z *Fp2) Frob(x *Fp2)       { *z = *x; z.Cjg() }
 func (z *Fp2) Cjg()              { z[1].Neg() }
+func (z *Fp2) Normalize()        { z[0].Normalize(); z[1].Normalize() }
 func (z *Fp2) Neg()              { z[0].Neg(); z[1].Neg() }
 func (z *Fp2) Add(x, y *Fp2)     { z[0].Add(&x[0], &y[0]); z[1].Add(&x[1], &y[1]) }
 func (z *Fp2) Sub(x, y *Fp2)     { z[0].Sub(&x[0], &y[0]); z[1].Sub(&x[1], &y[1]) }
@@ -32,6 +37,49 @@ func (z *Fp2) Mul(x, y *Fp2) {
 	z[1].Sub(&z[1], &x1y1)
 }
 
+// MulByNonResidue calculates z=x*ξ, where ξ=u+1 is the non-residue used to
+// build Fp6 = Fp2[v]/(v^3-ξ). It is the same as MulBeta.
+func (z *Fp2) MulByNonResidue(x *Fp2) { *z = *x; z.MulBeta() }
+
+// MulByNonResidueInv calculates z=x/ξ, where ξ=u+1 is the non-residue used
+// to build Fp6, i.e., it reverts MulByNonResidue. It uses that
+// 1/(1+u) = (1-u)/2, as u^2=-1.
+func (z *Fp2) MulByNonResidueInv(x *Fp2) {
+	t := x[0]
+	z[0].Add(&x[0], &x[1])
+	z[1].Sub(&x[1], &t)
+	z.Halve()
+}
+
+// Double calculates z=2z.
+func (z *Fp2) Double() { z.Add(z, z) }
+
+// Halve calculates z=z/2.
+func (z *Fp2) Halve() { z[0].Halve(); z[1].Halve() }
+
+// Triple calculates z=3z.
+func (z *Fp2) Triple() { t := *z; z.Add(z, z); z.Add(z, &t) }
+
+// MulBySmallInt calculates z=k*z. It runs in time that depends on k, but not
+// on z.
+func (z *Fp2) MulBySmallInt(k int) {
+	n := uint(k)
+	if k < 0 {
+		n = uint(-k)
+	}
+	t := *z
+	*z = Fp2{}
+	for i := bits.Len(n) - 1; i >= 0; i-- {
+		z.Double()
+		if (n>>uint(i))&1 == 1 {
+			z.Add(z, &t)
+		}
+	}
+	if k < 0 {
+		z.Neg()
+	}
+}
+
 func (z *Fp2) Sqr(x *Fp2) {
 	var x02, x12, k Fp
 	x02.Sqr(&x[0])
@@ -58,9 +106,14 @@ func (z Fp2) Sgn0() int {
 	return s0 | (z0 & s1)
 }
 
+// Cmp returns -1, 0 or +1 depending on whether z is less than, equal to, or
+// greater than x in lexicographic order, where z[1] is compared first. This is
+// the order of the encodings given by MarshalBinary.
+func (z Fp2) Cmp(x *Fp2) int { return cmpLex(z[1].Cmp(&x[1]), z[0].Cmp(&x[0])) }
+
 func (z *Fp2) UnmarshalBinary(b []byte) error {
 	if len(b) < Fp2Size {
-		return errInputLength
+		return decodeError("Fp2", ErrWrongLength)
 	}
 	return errFirst(
 		z[1].UnmarshalBinary(b[:FpSize]),
@@ -92,6 +145,73 @@ func (z *Fp2) CMov(x, y *Fp2, b int) {
 	z[1].CMov(&x[1], &y[1], b)
 }
 
+// Exp calculates z=x^n, where n is the exponent in big-endian order. As for
+// Fp12.Exp, it uses a fixed window of 4 bits, so its running time only
+// depends on len(n), and n can be secret.
+func (z *Fp2) Exp(x *Fp2, n []byte) {
+	zz := new(Fp2)
+	zz.SetOne()
+	T := new(Fp2)
+	var mults [16]Fp2
+	mults[0].SetOne()
+	mults[1] = *x
+	for i := 1; i < 8; i++ {
+		mults[2*i] = mults[i]
+		mults[2*i].Sqr(&mults[2*i])
+		mults[2*i+1].Mul(&mults[2*i], x)
+	}
+	N := 8 * len(n)
+	for i := 0; i < N; i += 4 {
+		zz.Sqr(zz)
+		zz.Sqr(zz)
+		zz.Sqr(zz)
+		zz.Sqr(zz)
+		idx := 0xf & (n[i/8] >> uint(4-i%8))
+		for j := 0; j < 16; j++ {
+			T.CMov(T, &mults[j], subtle.ConstantTimeByteEq(idx, uint8(j)))
+		}
+		zz.Mul(zz, T)
+	}
+	*z = *zz
+}
+
+// InvBatchFp2 sets out[i] to the inverse of in[i] using Montgomery's trick,
+// which costs one inversion and 3(n-1) multiplications in Fp2. As for Inv,
+// the inverse of zero is zero, and zeros do not affect the other outputs nor
+// the running time. The slices may be the same, but must not overlap
+// otherwise. It panics if they have different lengths.
+func InvBatchFp2(out, in []Fp2) {
+	if len(out) != len(in) {
+		panic("ff: mismatch length of inputs")
+	}
+	if len(in) == 0 {
+		return
+	}
+	var one, acc, inv, t Fp2
+	one.SetOne()
+	// prods[i] is the product of in[0..i], where zeros are replaced by one.
+	prods := make([]Fp2, len(in))
+	isZero := make([]int, len(in))
+	acc.SetOne()
+	for i := range in {
+		isZero[i] = in[i].IsZero()
+		t.CMov(&in[i], &one, isZero[i])
+		acc.Mul(&acc, &t)
+		prods[i] = acc
+	}
+	inv.Inv(&acc)
+	for i := len(in) - 1; i >= 0; i-- {
+		t.CMov(&in[i], &one, isZero[i])
+		if i > 0 {
+			out[i].Mul(&inv, &prods[i-1])
+		} else {
+			out[i] = inv
+		}
+		inv.Mul(&inv, &t)
+		out[i].CMov(&out[i], &Fp2{}, isZero[i])
+	}
+}
+
 // ExpVarTime calculates z=x^n, where n is the exponent in big-endian order.
 func (z *Fp2) ExpVarTime(x *Fp2, n []byte) {
 	zz := new(Fp2)
@@ -136,6 +256,29 @@ func (z *Fp2) Sqrt(x *Fp2) int {
 	return e1 | e2 | e3 | e4
 }
 
+// SqrtCT sets z to a square root of x and returns 1 if x is a
+// quadratic-residue; otherwise, it sets z=0 and returns 0. Unlike Sqrt, z is
+// always written, and every candidate root is computed and checked by
+// squaring, so the running time does not depend on whether x is a square.
+func (z *Fp2) SqrtCT(x *Fp2) (isSquare int) {
+	var t, tv1, root Fp2
+	// The exponent is public, so the running time of ExpVarTime does not
+	// depend on x.
+	tv1.ExpVarTime(x, fp2SqrtConst.c4[:])
+	for _, c := range []*Fp2{nil, &fp2SqrtConst.c1, &fp2SqrtConst.c2, &fp2SqrtConst.c3} {
+		cand := tv1
+		if c != nil {
+			cand.Mul(c, &tv1)
+		}
+		t.Sqr(&cand)
+		e := t.IsEqual(x)
+		root.CMov(&root, &cand, e)
+		isSquare |= e
+	}
+	*z = root
+	return isSquare
+}
+
 var fp2SqrtConst = struct {
 	// "Square-root for q = p^2 = 9 (mod 16)" Appendix I.3 of Hashing to elliptic curves.
 	// https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-hash-to-curve-11#appendix-I.3
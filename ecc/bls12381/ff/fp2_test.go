@@ -1,7 +1,10 @@
 package ff
 
 import (
+	"bytes"
+	"crypto/rand"
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/cloudflare/circl/internal/test"
@@ -9,6 +12,43 @@ import (
 
 func randomFp2(t testing.TB) *Fp2 { return &Fp2{*randomFp(t), *randomFp(t)} }
 
+// bigFp2 is a reference implementation of Fp2 = Fp[u]/(u^2+1) with big.Int.
+type bigFp2 [2]*big.Int
+
+func toBigFp2(t testing.TB, x *Fp2) (z bigFp2) {
+	for i := range z {
+		b, err := x[i].MarshalBinary()
+		test.CheckNoErr(t, err, "MarshalBinary failed")
+		z[i] = new(big.Int).SetBytes(b)
+	}
+	return
+}
+
+func (x bigFp2) mul(y bigFp2) bigFp2 {
+	p := new(big.Int).SetBytes(FpOrder())
+	t := new(big.Int)
+	z0 := new(big.Int).Mul(x[0], y[0])
+	z0.Sub(z0, t.Mul(x[1], y[1]))
+	z1 := new(big.Int).Mul(x[0], y[1])
+	z1.Add(z1, t.Mul(x[1], y[0]))
+	return bigFp2{z0.Mod(z0, p), z1.Mod(z1, p)}
+}
+
+func (x bigFp2) exp(n []byte) bigFp2 {
+	z := bigFp2{big.NewInt(1), big.NewInt(0)}
+	for _, b := range n {
+		for j := 7; j >= 0; j-- {
+			z = z.mul(z)
+			if (b>>uint(j))&1 == 1 {
+				z = z.mul(x)
+			}
+		}
+	}
+	return z
+}
+
+func (x bigFp2) isEqual(y bigFp2) bool { return x[0].Cmp(y[0]) == 0 && x[1].Cmp(y[1]) == 0 }
+
 func TestFp2(t *testing.T) {
 	const testTimes = 1 << 9
 	t.Run("no_alias", func(t *testing.T) {
@@ -60,6 +100,97 @@ func TestFp2(t *testing.T) {
 			}
 		}
 	})
+	t.Run("small_int", func(t *testing.T) {
+		var u, k Fp2
+		u[0].SetOne()
+		u[1].SetOne()
+		for i := 0; i < testTimes; i++ {
+			x := randomFp2(t)
+
+			got, want := *x, *x
+			got.MulByNonResidue(&got)
+			want.Mul(&want, &u)
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x)
+			}
+
+			got, want = *x, *x
+			got.Double()
+			k = Fp2{}
+			k[0].SetUint64(2)
+			want.Mul(&want, &k)
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x)
+			}
+
+			got, want = *x, *x
+			got.Triple()
+			k[0].SetUint64(3)
+			want.Mul(&want, &k)
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x)
+			}
+
+			for _, n := range []int{0, 1, -1, 4, -7, 12, 255, -1000} {
+				got, want = *x, *x
+				got.MulBySmallInt(n)
+				k = Fp2{}
+				if n < 0 {
+					k[0].SetUint64(uint64(-n))
+					k[0].Neg()
+				} else {
+					k[0].SetUint64(uint64(n))
+				}
+				want.Mul(&want, &k)
+				if got.IsEqual(&want) == 0 {
+					test.ReportError(t, got, want, x, n)
+				}
+			}
+		}
+	})
+	t.Run("double_halve", func(t *testing.T) {
+		var got, want, half Fp2
+		half[0].SetUint64(2)
+		half.Inv(&half)
+		for i := 0; i < testTimes; i++ {
+			x := randomFp2(t)
+			got = *x
+			got.Halve()
+			want.Mul(x, &half)
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x)
+			}
+			got.Double()
+			if got.IsEqual(x) == 0 {
+				test.ReportError(t, got, x)
+			}
+		}
+	})
+	t.Run("non_residue", func(t *testing.T) {
+		var got, want, xiInv Fp2
+		xi := Fp6NonResidue()
+		xiInv.Inv(xi)
+		for i := 0; i < testTimes; i++ {
+			x := randomFp2(t)
+
+			got.MulByNonResidueInv(x)
+			want.Mul(x, &xiInv)
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x)
+			}
+
+			alias := *x
+			alias.MulByNonResidueInv(&alias)
+			if alias.IsEqual(&want) == 0 {
+				test.ReportError(t, alias, want, x)
+			}
+
+			got.MulByNonResidue(&got)
+			if got.IsEqual(x) == 0 {
+				test.ReportError(t, got, x)
+			}
+		}
+	})
 	t.Run("sqrt", func(t *testing.T) {
 		var r, notRoot, got Fp2
 		// Check when x has square-root.
@@ -101,6 +232,96 @@ func TestFp2(t *testing.T) {
 			}
 		}
 	})
+	t.Run("sqrtCT", func(t *testing.T) {
+		var uPlus1 Fp2
+		uPlus1[0].SetUint64(1)
+		uPlus1[1].SetUint64(1)
+		for i := 0; i < testTimes; i++ {
+			x := randomFp2(t)
+			x.Sqr(x)
+
+			var got, want Fp2
+			isQR := got.SqrtCT(x)
+			test.CheckOk(isQR == 1, fmt.Sprintf("should be a QR: %v", x), t)
+			_ = want.Sqrt(x)
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x)
+			}
+
+			x.Mul(x, &uPlus1) // x = (u+1)*(x^2) is not QR in Fp2.
+			isQR = got.SqrtCT(x)
+			test.CheckOk(isQR == 0, fmt.Sprintf("shouldn't be a QR: %v", x), t)
+			if got.IsZero() != 1 {
+				test.ReportError(t, got, Fp2{}, x)
+			}
+		}
+	})
+	t.Run("exp", func(t *testing.T) {
+		n := make([]byte, 48)
+		for i := 0; i < 1<<5; i++ {
+			x := randomFp2(t)
+			e := n[:1+i%len(n)]
+			_, _ = rand.Read(e)
+
+			var got, gotVarTime Fp2
+			got.Exp(x, e)
+			gotVarTime.ExpVarTime(x, e)
+			want := toBigFp2(t, x).exp(e)
+			if !toBigFp2(t, &got).isEqual(want) || got.IsEqual(&gotVarTime) == 0 {
+				test.ReportError(t, got, want, x, e)
+			}
+		}
+	})
+	t.Run("inv_batch", func(t *testing.T) {
+		one := bigFp2{big.NewInt(1), big.NewInt(0)}
+		for _, n := range []int{0, 1, 2, 7, 32} {
+			in := make([]Fp2, n)
+			for i := range in {
+				in[i] = *randomFp2(t)
+			}
+			if n > 2 {
+				in[n/2] = Fp2{}
+			}
+			out := make([]Fp2, n)
+			InvBatchFp2(out, in)
+			for i := range in {
+				if in[i].IsZero() == 1 {
+					if out[i].IsZero() != 1 {
+						test.ReportError(t, out[i], Fp2{}, in[i])
+					}
+					continue
+				}
+				got := toBigFp2(t, &out[i]).mul(toBigFp2(t, &in[i]))
+				if !got.isEqual(one) {
+					test.ReportError(t, got, one, in[i])
+				}
+			}
+
+			// In place.
+			InvBatchFp2(in, in)
+			for i := range in {
+				if in[i].IsEqual(&out[i]) == 0 {
+					test.ReportError(t, in[i], out[i], i)
+				}
+			}
+		}
+		err := test.CheckPanic(func() { InvBatchFp2(make([]Fp2, 1), make([]Fp2, 2)) })
+		test.CheckNoErr(t, err, "InvBatchFp2 must panic on mismatched lengths")
+	})
+	t.Run("cmp", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			x := randomFp2(t)
+			y0, y1 := *randomFp2(t), *x
+			y1[0] = *randomFp(t)
+			for _, y := range []*Fp2{&y0, &y1, x} {
+				bx, _ := x.MarshalBinary()
+				by, _ := y.MarshalBinary()
+				if got, want := x.Cmp(y), bytes.Compare(bx, by); got != want {
+					test.ReportError(t, got, want, x, y)
+				}
+			}
+		}
+	})
 	t.Run("marshal", func(t *testing.T) {
 		var b Fp2
 		for i := 0; i < testTimes; i++ {
@@ -120,6 +341,31 @@ func BenchmarkFp2(b *testing.B) {
 	x := randomFp2(b)
 	y := randomFp2(b)
 	z := randomFp2(b)
+	n := make([]byte, 48)
+	_, _ = rand.Read(n)
+	b.Run("Exp", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z.Exp(x, n)
+		}
+	})
+	const batchSize = 64
+	in := make([]Fp2, batchSize)
+	out := make([]Fp2, batchSize)
+	for i := range in {
+		in[i] = *randomFp2(b)
+	}
+	b.Run(fmt.Sprintf("InvBatch/%v", batchSize), func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			InvBatchFp2(out, in)
+		}
+	})
+	b.Run(fmt.Sprintf("Inv/%v", batchSize), func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := range in {
+				out[j].Inv(&in[j])
+			}
+		}
+	})
 	b.Run("Add", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			z.Add(x, y)
@@ -140,4 +386,9 @@ func BenchmarkFp2(b *testing.B) {
 			z.Inv(x)
 		}
 	})
+	b.Run("MulByNonResidue", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z.MulByNonResidue(z)
+		}
+	})
 }
@@ -0,0 +1,51 @@
+//go:build timing
+// +build timing
+
+package ff
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+// TestFp2SqrtCTTiming compares the running time of SqrtCT on squares and
+// non-squares. It is sensitive to noise, so it only runs with the timing
+// build tag: go test -tags timing -run Timing.
+func TestFp2SqrtCTTiming(t *testing.T) {
+	const testTimes = 1 << 10
+	const maxRelDiff = 0.05
+
+	var uPlus1 Fp2
+	uPlus1[0].SetUint64(1)
+	uPlus1[1].SetUint64(1)
+	inputs := [2][]*Fp2{}
+	for i := 0; i < testTimes; i++ {
+		x := randomFp2(t)
+		x.Sqr(x)
+		y := *x
+		y.Mul(&y, &uPlus1)
+		inputs[0] = append(inputs[0], x)
+		inputs[1] = append(inputs[1], &y)
+	}
+
+	var z Fp2
+	var times [2][]time.Duration
+	for i := 0; i < testTimes; i++ {
+		// Alternate the classes to spread the noise evenly.
+		for c := range inputs {
+			start := time.Now()
+			z.SqrtCT(inputs[c][i])
+			times[c] = append(times[c], time.Since(start))
+		}
+	}
+
+	median := func(d []time.Duration) float64 {
+		sort.Slice(d, func(i, j int) bool { return d[i] < d[j] })
+		return float64(d[len(d)/2])
+	}
+	squares, nonSquares := median(times[0]), median(times[1])
+	if diff := (squares - nonSquares) / squares; diff > maxRelDiff || diff < -maxRelDiff {
+		t.Errorf("median times differ: squares %v ns, non-squares %v ns", squares, nonSquares)
+	}
+}
@@ -5,6 +5,8 @@ import "fmt"
 // Fp6Size is the length in bytes of an Fp6 element.
 const Fp6Size = 3 * Fp2Size
 
+// Fp6 represents an element of the field Fp6 = Fp2[v]/(v^3-u-1), where the
+// coordinate z[i] is the coefficient of v^i.
 type Fp6 [3]Fp2
 
 func (z Fp6) String() string { return fmt.Sprintf("\n0: %v\n1: %v\n2: %v", z[0], z[1], z[2]) }
@@ -14,8 +16,16 @@ func (z Fp6) IsEqual(x *Fp6) int {
 	return z[0].IsEqual(&x[0]) & z[1].IsEqual(&x[1]) & z[2].IsEqual(&x[2])
 }
 func (z *Fp6) Neg()          { z[0].Neg(); z[1].Neg(); z[2].Neg() }
+func (z *Fp6) Normalize()    { z[0].Normalize(); z[1].Normalize(); z[2].Normalize() }
 func (z *Fp6) Add(x, y *Fp6) { z[0].Add(&x[0], &y[0]); z[1].Add(&x[1], &y[1]); z[2].Add(&x[2], &y[2]) }
 func (z *Fp6) Sub(x, y *Fp6) { z[0].Sub(&x[0], &y[0]); z[1].Sub(&x[1], &y[1]); z[2].Sub(&x[2], &y[2]) }
+
+// Double calculates z=2z.
+func (z *Fp6) Double() { z[0].Double(); z[1].Double(); z[2].Double() }
+
+// Halve calculates z=z/2.
+func (z *Fp6) Halve() { z[0].Halve(); z[1].Halve(); z[2].Halve() }
+
 func (z *Fp6) MulBeta() {
 	t := z[2]
 	t.MulBeta()
@@ -24,6 +34,21 @@ func (z *Fp6) MulBeta() {
 	z[0] = t
 }
 
+// MulByNonResidue calculates z=x*v, where v is the non-residue used to build
+// Fp12 = Fp6[w]/(w^2-v).
+func (z *Fp6) MulByNonResidue(x *Fp6) { *z = *x; z.MulBeta() }
+
+// MulByNonResidueInv calculates z=x/v, where v is the non-residue used to
+// build Fp12, i.e., it reverts MulByNonResidue. Since v^3=ξ, then
+// (x0 + x1*v + x2*v^2)/v = x1 + x2*v + (x0/ξ)*v^2.
+func (z *Fp6) MulByNonResidueInv(x *Fp6) {
+	var t Fp2
+	t.MulByNonResidueInv(&x[0])
+	z[0] = x[1]
+	z[1] = x[2]
+	z[2] = t
+}
+
 func (z *Fp6) Mul(x, y *Fp6) {
 	// https://ia.cr/2006/224 (Sec3.1)
 	//  z = x*y mod (v^3-B)
@@ -70,6 +95,46 @@ func (z *Fp6) Mul(x, y *Fp6) {
 	z[0].Sub(&z[0], c2) // z0 = B(c5-c1)-Bc2+c0 = B(c5-c1-c2)+c0
 }
 
+// MulBy01 calculates z=x*(c0 + c1*v), i.e., the product by an element whose
+// coefficient of v^2 is zero, using five multiplications in Fp2 instead of
+// six. It is used by Fp12.MulBy014.
+func (z *Fp6) MulBy01(x *Fp6, c0, c1 *Fp2) {
+	//  z0 = a0*c0 + B(a2*c1)
+	//  z1 = a0*c1 + a1*c0
+	//  z2 = a1*c1 + a2*c0
+	a0, a1, a2 := &x[0], &x[1], &x[2]
+	t0, t1, tx, ty, tc := &Fp2{}, &Fp2{}, &Fp2{}, &Fp2{}, &Fp2{}
+	t0.Mul(a0, c0)
+	t1.Mul(a1, c1)
+
+	tx.Add(a1, a2)
+	tx.Mul(tx, c1)
+	tx.Sub(tx, t1)
+	tx.MulBeta()
+	tx.Add(tx, t0) // z0 = B((a1+a2)*c1-a1*c1)+a0*c0
+
+	ty.Add(a0, a1)
+	tc.Add(c0, c1)
+	ty.Mul(ty, tc)
+	ty.Sub(ty, t0)
+	ty.Sub(ty, t1) // z1 = (a0+a1)*(c0+c1)-a0*c0-a1*c1
+
+	z[2].Mul(a2, c0)
+	z[2].Add(&z[2], t1) // z2 = a2*c0+a1*c1
+	z[0] = *tx
+	z[1] = *ty
+}
+
+// MulBy1 calculates z=x*(c1*v) using three multiplications in Fp2.
+func (z *Fp6) MulBy1(x *Fp6, c1 *Fp2) {
+	t := &Fp2{}
+	t.Mul(&x[2], c1)
+	t.MulBeta()
+	z[2].Mul(&x[1], c1)
+	z[1].Mul(&x[0], c1)
+	z[0] = *t
+}
+
 func (z *Fp6) Sqr(x *Fp6) {
 	//  z = x^2 mod (v^3-B)
 	// z0 = B(2x1*x2) + x0^2
@@ -142,6 +207,13 @@ func (z *Fp6) CMov(x, y *Fp6, b int) {
 	z[2].CMov(&x[2], &y[2], b)
 }
 
+// Cmp returns -1, 0 or +1 depending on whether z is less than, equal to, or
+// greater than x in lexicographic order, where z[2] is compared first and
+// z[0] last. This is the order of the encodings given by MarshalBinary.
+func (z Fp6) Cmp(x *Fp6) int {
+	return cmpLex(z[2].Cmp(&x[2]), cmpLex(z[1].Cmp(&x[1]), z[0].Cmp(&x[0])))
+}
+
 func (z Fp6) MarshalBinary() (b []byte, e error) {
 	var b0, b1, b2 []byte
 	if b2, e = z[2].MarshalBinary(); e == nil {
@@ -156,7 +228,7 @@ func (z Fp6) MarshalBinary() (b []byte, e error) {
 
 func (z *Fp6) UnmarshalBinary(b []byte) error {
 	if len(b) < Fp6Size {
-		return errInputLength
+		return decodeError("Fp6", ErrWrongLength)
 	}
 	return errFirst(
 		z[2].UnmarshalBinary(b[0*Fp2Size:1*Fp2Size]),
@@ -1,6 +1,7 @@
 package ff
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/cloudflare/circl/internal/test"
@@ -36,6 +37,37 @@ func TestFp6(t *testing.T) {
 			test.ReportError(t, got, want, x)
 		}
 	})
+	t.Run("mul_by_01", func(t *testing.T) {
+		var want, got Fp6
+		for i := 0; i < testTimes; i++ {
+			x := randomFp6(t)
+			c0, c1 := randomFp2(t), randomFp2(t)
+
+			// x*(c0 + c1*v)
+			got.MulBy01(x, c0, c1)
+			want.Mul(x, &Fp6{*c0, *c1, Fp2{}})
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x, c0, c1)
+			}
+			got = *x
+			got.MulBy01(&got, c0, c1)
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x, c0, c1, "aliased")
+			}
+
+			// x*(c1*v)
+			got.MulBy1(x, c1)
+			want.Mul(x, &Fp6{Fp2{}, *c1, Fp2{}})
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x, c1)
+			}
+			got = *x
+			got.MulBy1(&got, c1)
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x, c1, "aliased")
+			}
+		}
+	})
 	t.Run("mul_inv", func(t *testing.T) {
 		var z Fp6
 		for i := 0; i < testTimes; i++ {
@@ -74,6 +106,64 @@ func TestFp6(t *testing.T) {
 			}
 		}
 	})
+	t.Run("double_halve", func(t *testing.T) {
+		var got, want, half Fp6
+		half[0][0].SetUint64(2)
+		half.Inv(&half)
+		for i := 0; i < testTimes; i++ {
+			x := randomFp6(t)
+			got = *x
+			got.Halve()
+			want.Mul(x, &half)
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x)
+			}
+			got.Double()
+			if got.IsEqual(x) == 0 {
+				test.ReportError(t, got, x)
+			}
+		}
+	})
+	t.Run("non_residue", func(t *testing.T) {
+		var got, want, vInv Fp6
+		v := Fp12NonResidue()
+		vInv.Inv(v)
+		for i := 0; i < testTimes; i++ {
+			x := randomFp6(t)
+
+			got.MulByNonResidue(x)
+			want.Mul(x, v)
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x)
+			}
+
+			got.MulByNonResidueInv(&got)
+			if got.IsEqual(x) == 0 {
+				test.ReportError(t, got, x)
+			}
+
+			got.MulByNonResidueInv(x)
+			want.Mul(x, &vInv)
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x)
+			}
+		}
+	})
+	t.Run("cmp", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			x := randomFp6(t)
+			y0, y1, y2 := *randomFp6(t), *x, *x
+			y1[0] = *randomFp2(t)
+			y2[0], y2[1] = *randomFp2(t), *randomFp2(t)
+			for _, y := range []*Fp6{&y0, &y1, &y2, x} {
+				bx, _ := x.MarshalBinary()
+				by, _ := y.MarshalBinary()
+				if got, want := x.Cmp(y), bytes.Compare(bx, by); got != want {
+					test.ReportError(t, got, want, x, y)
+				}
+			}
+		}
+	})
 	t.Run("frobenius", func(t *testing.T) {
 		var got, want Fp6
 		p := FpOrder()
@@ -105,6 +195,11 @@ func BenchmarkFp6(b *testing.B) {
 			z.Mul(x, y)
 		}
 	})
+	b.Run("MulBy01", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z.MulBy01(x, &y[0], &y[1])
+		}
+	})
 	b.Run("Sqr", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			z.Sqr(x)
@@ -1,10 +1,14 @@
 package ff
 
 import (
+	"bytes"
 	"crypto/rand"
 	"fmt"
+	"math/big"
+	"math/bits"
 	"testing"
 
+	"github.com/cloudflare/circl/internal/conv"
 	"github.com/cloudflare/circl/internal/test"
 )
 
@@ -18,6 +22,16 @@ func randomFp(t testing.TB) *Fp {
 	return f
 }
 
+// unreduceFp adds FpOrder to the internal representation of x, which
+// represents the same element but is not in canonical form.
+func unreduceFp(x *Fp) {
+	var c uint64
+	p := conv.BytesBe2Uint64Le(fpOrder[:])
+	for i := range x.i {
+		x.i[i], c = bits.Add64(x.i[i], p[i], c)
+	}
+}
+
 func TestFp(t *testing.T) {
 	const testTimes = 1 << 10
 	t.Run("no_alias", func(t *testing.T) {
@@ -107,6 +121,27 @@ func TestFp(t *testing.T) {
 			}
 		}
 	})
+	t.Run("inv_safegcd", func(t *testing.T) {
+		var got, want, one, pMinus1 Fp
+		one.SetOne()
+		pMinus1.SetOne()
+		pMinus1.Neg()
+		for _, x := range []*Fp{{}, &one, &pMinus1} {
+			got.InvSafeGCD(x)
+			want.invFermat(x)
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x)
+			}
+		}
+		for i := 0; i < 4*testTimes; i++ {
+			x := randomFp(t)
+			got.InvSafeGCD(x)
+			want.invFermat(x)
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x)
+			}
+		}
+	})
 	t.Run("marshal", func(t *testing.T) {
 		var b Fp
 		for i := 0; i < testTimes; i++ {
@@ -120,6 +155,218 @@ func TestFp(t *testing.T) {
 			}
 		}
 	})
+	t.Run("uniform_bytes", func(t *testing.T) {
+		var got, want Fp
+		p := new(big.Int).SetBytes(FpOrder())
+		for _, n := range []int{FpUniformSize, FpUniformSize + 1, 80, 96, 200} {
+			b := make([]byte, n)
+			for i := 0; i < testTimes/8; i++ {
+				_, _ = rand.Read(b)
+				if i == 0 {
+					for j := range b {
+						b[j] = 0xff
+					}
+				}
+				got.SetUniformBytes(b)
+				want.SetBytes(b)
+				if got.IsEqual(&want) == 0 {
+					test.ReportError(t, got, want, b)
+				}
+
+				// The internal representation is canonical.
+				c := got
+				c.Normalize()
+				if c.IsEqual(&got) == 0 {
+					test.ReportError(t, c, got, b)
+				}
+				s, _ := got.MarshalBinary()
+				if new(big.Int).SetBytes(s).Cmp(p) >= 0 {
+					test.ReportError(t, s, p, b)
+				}
+			}
+		}
+		err := test.CheckPanic(func() { got.SetUniformBytes(make([]byte, FpUniformSize-1)) })
+		test.CheckNoErr(t, err, "SetUniformBytes should panic on short input")
+	})
+	t.Run("random_fast", func(t *testing.T) {
+		// Chi-squared test of the least significant byte of the elements,
+		// with 255 degrees of freedom. The threshold has a false-positive
+		// rate below 10^-6.
+		const buckets, samples, threshold = 256, 256 * 64, 380
+		var count [buckets]int
+		for i := 0; i < samples; i++ {
+			x, err := RandomFpFast(rand.Reader)
+			test.CheckNoErr(t, err, "RandomFpFast failed")
+			s, _ := x.MarshalBinary()
+			count[s[FpSize-1]]++
+		}
+		expected := float64(samples) / buckets
+		chi2 := 0.0
+		for _, c := range count {
+			d := float64(c) - expected
+			chi2 += d * d / expected
+		}
+		if chi2 > threshold {
+			test.ReportError(t, chi2, threshold)
+		}
+
+		_, err := RandomFpFast(bytes.NewReader(make([]byte, FpUniformSize-1)))
+		test.CheckIsErr(t, err, "RandomFpFast should fail on short reads")
+	})
+	t.Run("double_halve", func(t *testing.T) {
+		var got, want, half Fp
+		half.SetUint64(2)
+		half.Inv(&half)
+		for i := 0; i < testTimes; i++ {
+			x := randomFp(t)
+			if i == 0 {
+				x.SetOne()
+				x.Neg()
+			}
+
+			got = *x
+			got.Halve()
+			want.Mul(x, &half)
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x)
+			}
+
+			got.Double()
+			if got.IsEqual(x) == 0 {
+				test.ReportError(t, got, x)
+			}
+			got.Double()
+			want.Add(x, x)
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, x)
+			}
+		}
+	})
+	t.Run("is_canonical", func(t *testing.T) {
+		one := fpRaw{1}
+		var pMinus1, p, pPlus1 Fp
+		var c uint64
+		p.i = fpOrderWords
+		for i := range one {
+			pMinus1.i[i], c = bits.Sub64(fpOrderWords[i], one[i], c)
+		}
+		c = 0
+		for i := range one {
+			pPlus1.i[i], c = bits.Add64(fpOrderWords[i], one[i], c)
+		}
+		allOnes := Fp{fpMont{^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0)}}
+		for _, v := range []struct {
+			x    Fp
+			want int
+		}{{Fp{}, 1}, {pMinus1, 1}, {p, 0}, {pPlus1, 0}, {allOnes, 0}} {
+			x := v.x
+			if got := x.IsCanonical(); got != v.want {
+				test.ReportError(t, got, v.want, x)
+			}
+			x.Normalize()
+			if got := x.IsCanonical(); got != 1 {
+				test.ReportError(t, got, 1, v.x)
+			}
+		}
+
+		for i := 0; i < testTimes; i++ {
+			x := randomFp(t)
+			if got := x.IsCanonical(); got != 1 {
+				test.ReportError(t, got, 1, x)
+			}
+			y := *x
+			unreduceFp(&y)
+			if got := y.IsCanonical(); got != 0 {
+				test.ReportError(t, got, 0, x)
+			}
+			y.Normalize()
+			if y.IsEqual(x) == 0 {
+				test.ReportError(t, y, x)
+			}
+		}
+	})
+	t.Run("cmp", func(t *testing.T) {
+		var small Fp
+		small.SetUint64(3)
+		for i := 0; i < testTimes; i++ {
+			x, y := randomFp(t), randomFp(t)
+			for _, v := range []*Fp{y, x, &small} {
+				bx, _ := x.MarshalBinary()
+				bv, _ := v.MarshalBinary()
+				got := x.Cmp(v)
+				want := new(big.Int).SetBytes(bx).Cmp(new(big.Int).SetBytes(bv))
+				if got != want {
+					test.ReportError(t, got, want, x, v)
+				}
+			}
+		}
+	})
+	t.Run("accumulator", func(t *testing.T) {
+		for _, n := range []int{0, 1, 2, 100} {
+			var acc FpAccumulator
+			var want, prod Fp
+			for i := 0; i < n; i++ {
+				a, b := randomFp(t), randomFp(t)
+				acc.AddMul(a, b)
+				prod.Mul(a, b)
+				want.Add(&want, &prod)
+			}
+			got := acc.Sum()
+			if got.IsEqual(&want) == 0 {
+				test.ReportError(t, got, want, n)
+			}
+		}
+
+		// Large terms (p-1)^2 carry into the top word, which is then
+		// bumped to check the reduction of its full range.
+		var acc FpAccumulator
+		var want, prod, minusOne Fp
+		minusOne.SetOne()
+		minusOne.Neg()
+		for i := 0; i < 1<<12; i++ {
+			acc.AddMul(&minusOne, &minusOne)
+			prod.Mul(&minusOne, &minusOne)
+			want.Add(&want, &prod)
+		}
+		acc.acc[fpAccSize-1] += 1 << 20
+		fiatFpMontMul(&prod.i, &fpMont{1 << 20}, &fpRSquare) // 2^20*R
+		want.Add(&want, &prod)
+		if got := acc.Sum(); got.IsEqual(&want) == 0 {
+			test.ReportError(t, got, want)
+		}
+
+		acc.Reset()
+		if got := acc.Sum(); got.IsZero() != 1 {
+			test.ReportError(t, got, 0)
+		}
+	})
+}
+
+func BenchmarkFpAccumulator(b *testing.B) {
+	const n = 1000
+	x, y := make([]Fp, n), make([]Fp, n)
+	for i := range x {
+		x[i] = *randomFp(b)
+		y[i] = *randomFp(b)
+	}
+	b.Run("Naive", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var z, t Fp
+			for j := range x {
+				t.Mul(&x[j], &y[j])
+				z.Add(&z, &t)
+			}
+		}
+	})
+	b.Run("Accumulator", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var acc FpAccumulator
+			for j := range x {
+				acc.AddMul(&x[j], &y[j])
+			}
+			_ = acc.Sum()
+		}
+	})
 }
 
 func BenchmarkFp(b *testing.B) {
@@ -141,9 +388,19 @@ func BenchmarkFp(b *testing.B) {
 			z.Sqr(x)
 		}
 	})
+	b.Run("Halve", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z.Halve()
+		}
+	})
 	b.Run("Inv", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			z.Inv(x)
 		}
 	})
+	b.Run("InvFermat", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z.invFermat(x)
+		}
+	})
 }
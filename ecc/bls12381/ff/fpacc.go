@@ -0,0 +1,135 @@
+package ff
+
+import "math/bits"
+
+// fpAccSize is the number of words of the accumulator of FpAccumulator.
+const fpAccSize = 2*FpSize/8 + 1
+
+// FpAccumulator computes sums of products of Fp elements, such as inner
+// products, performing a single modular reduction at the end. Products are
+// added into an 832-bit register without reduction; as each product is less
+// than FpOrder^2 < 2^762, the accumulator can hold at least 2^64 terms
+// before overflowing. The zero value is an accumulator set to zero.
+type FpAccumulator struct{ acc [fpAccSize]uint64 }
+
+// Reset sets the accumulator to zero.
+func (s *FpAccumulator) Reset() { s.acc = [fpAccSize]uint64{} }
+
+// AddMul adds the product a*b to the accumulator. The product is computed
+// column by column (product scanning), and each column is added into the
+// accumulator with its carries.
+func (s *FpAccumulator) AddMul(a, b *Fp) {
+	x, y := &a.i, &b.i
+	var c, r0, r1, r2 uint64
+	r0, r1, r2 = mac(x[0], y[0], r0, r1, r2)
+	s.acc[0], c = bits.Add64(s.acc[0], r0, 0)
+	r0, c = bits.Add64(r1, 0, c)
+	r1, r2 = r2+c, 0
+
+	r0, r1, r2 = mac(x[0], y[1], r0, r1, r2)
+	r0, r1, r2 = mac(x[1], y[0], r0, r1, r2)
+	s.acc[1], c = bits.Add64(s.acc[1], r0, 0)
+	r0, c = bits.Add64(r1, 0, c)
+	r1, r2 = r2+c, 0
+
+	r0, r1, r2 = mac(x[0], y[2], r0, r1, r2)
+	r0, r1, r2 = mac(x[1], y[1], r0, r1, r2)
+	r0, r1, r2 = mac(x[2], y[0], r0, r1, r2)
+	s.acc[2], c = bits.Add64(s.acc[2], r0, 0)
+	r0, c = bits.Add64(r1, 0, c)
+	r1, r2 = r2+c, 0
+
+	r0, r1, r2 = mac(x[0], y[3], r0, r1, r2)
+	r0, r1, r2 = mac(x[1], y[2], r0, r1, r2)
+	r0, r1, r2 = mac(x[2], y[1], r0, r1, r2)
+	r0, r1, r2 = mac(x[3], y[0], r0, r1, r2)
+	s.acc[3], c = bits.Add64(s.acc[3], r0, 0)
+	r0, c = bits.Add64(r1, 0, c)
+	r1, r2 = r2+c, 0
+
+	r0, r1, r2 = mac(x[0], y[4], r0, r1, r2)
+	r0, r1, r2 = mac(x[1], y[3], r0, r1, r2)
+	r0, r1, r2 = mac(x[2], y[2], r0, r1, r2)
+	r0, r1, r2 = mac(x[3], y[1], r0, r1, r2)
+	r0, r1, r2 = mac(x[4], y[0], r0, r1, r2)
+	s.acc[4], c = bits.Add64(s.acc[4], r0, 0)
+	r0, c = bits.Add64(r1, 0, c)
+	r1, r2 = r2+c, 0
+
+	r0, r1, r2 = mac(x[0], y[5], r0, r1, r2)
+	r0, r1, r2 = mac(x[1], y[4], r0, r1, r2)
+	r0, r1, r2 = mac(x[2], y[3], r0, r1, r2)
+	r0, r1, r2 = mac(x[3], y[2], r0, r1, r2)
+	r0, r1, r2 = mac(x[4], y[1], r0, r1, r2)
+	r0, r1, r2 = mac(x[5], y[0], r0, r1, r2)
+	s.acc[5], c = bits.Add64(s.acc[5], r0, 0)
+	r0, c = bits.Add64(r1, 0, c)
+	r1, r2 = r2+c, 0
+
+	r0, r1, r2 = mac(x[1], y[5], r0, r1, r2)
+	r0, r1, r2 = mac(x[2], y[4], r0, r1, r2)
+	r0, r1, r2 = mac(x[3], y[3], r0, r1, r2)
+	r0, r1, r2 = mac(x[4], y[2], r0, r1, r2)
+	r0, r1, r2 = mac(x[5], y[1], r0, r1, r2)
+	s.acc[6], c = bits.Add64(s.acc[6], r0, 0)
+	r0, c = bits.Add64(r1, 0, c)
+	r1, r2 = r2+c, 0
+
+	r0, r1, r2 = mac(x[2], y[5], r0, r1, r2)
+	r0, r1, r2 = mac(x[3], y[4], r0, r1, r2)
+	r0, r1, r2 = mac(x[4], y[3], r0, r1, r2)
+	r0, r1, r2 = mac(x[5], y[2], r0, r1, r2)
+	s.acc[7], c = bits.Add64(s.acc[7], r0, 0)
+	r0, c = bits.Add64(r1, 0, c)
+	r1, r2 = r2+c, 0
+
+	r0, r1, r2 = mac(x[3], y[5], r0, r1, r2)
+	r0, r1, r2 = mac(x[4], y[4], r0, r1, r2)
+	r0, r1, r2 = mac(x[5], y[3], r0, r1, r2)
+	s.acc[8], c = bits.Add64(s.acc[8], r0, 0)
+	r0, c = bits.Add64(r1, 0, c)
+	r1, r2 = r2+c, 0
+
+	r0, r1, r2 = mac(x[4], y[5], r0, r1, r2)
+	r0, r1, r2 = mac(x[5], y[4], r0, r1, r2)
+	s.acc[9], c = bits.Add64(s.acc[9], r0, 0)
+	r0, c = bits.Add64(r1, 0, c)
+	r1, r2 = r2+c, 0
+
+	r0, r1, r2 = mac(x[5], y[5], r0, r1, r2)
+	s.acc[10], c = bits.Add64(s.acc[10], r0, 0)
+	r0, c = bits.Add64(r1, 0, c)
+	r1, r2 = r2+c, 0
+
+	s.acc[11], c = bits.Add64(s.acc[11], r0, 0)
+	s.acc[12] += r1 + c
+}
+
+// mac returns (r2:r1:r0) + x*y, as a 192-bit number.
+func mac(x, y, r0, r1, r2 uint64) (uint64, uint64, uint64) {
+	hi, lo := bits.Mul64(x, y)
+	r0, c := bits.Add64(r0, lo, 0)
+	r1, c = bits.Add64(r1, hi, c)
+	return r0, r1, r2 + c
+}
+
+// Sum returns the sum of the products accumulated so far, reduced modulo
+// FpOrder. The accumulator is not modified.
+func (s *FpAccumulator) Sum() *Fp {
+	// The products are of elements in the Montgomery domain, so the
+	// accumulator holds T = sum a_i*b_i*R^2, and the result is T/R mod p.
+	// Writing T = L + H*R and H = H0 + H1*R, it is
+	// T/R = L/R + H0 + H1*R (mod p).
+	var l, h0, h1 fpMont
+	copy(l[:], s.acc[:FpSize/8])
+	copy(h0[:], s.acc[FpSize/8:2*FpSize/8])
+	h1[0] = s.acc[fpAccSize-1]
+
+	var z, t Fp
+	fiatFpMontMul(&z.i, &l, &fpMont{1})
+	fiatFpMontMul(&t.i, &h0, &fpROne)
+	z.Add(&z, &t)
+	fiatFpMontMul(&t.i, &h1, &fpRSquare)
+	z.Add(&z, &t)
+	return &z
+}
@@ -0,0 +1,48 @@
+package ff
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// frobeniusCoeffs holds the constants used by the Frobenius maps of Fp6 and
+// Fp12, where xi = u+1 is the non-residue of Fp6 = Fp2[v]/(v^3-xi) and
+// Fp12 = Fp6[w]/(w^2-v). Since w^6 = xi, then w^p = w*xi^((p-1)/6),
+// v^p = v*xi^((p-1)/3) and (v^2)^p = v^2*xi^(2(p-1)/3).
+type frobeniusCoeffs struct{ w1, v1, v2 Fp2 }
+
+// computeFrobeniusCoeffs derives the Frobenius constants from the
+// non-residue xi.
+func computeFrobeniusCoeffs() (c frobeniusCoeffs) {
+	e := new(big.Int).SetBytes(fpOrder[:])
+	e.Sub(e, big.NewInt(1))
+	e.Div(e, big.NewInt(6))
+
+	var xi Fp2
+	xi.SetOne()
+	xi.MulBeta() // xi = u+1
+	c.w1.ExpVarTime(&xi, e.Bytes())
+	c.v1.Sqr(&c.w1)
+	c.v2.Sqr(&c.v1)
+	return
+}
+
+// ValidateFrobeniusTables checks that the hardcoded constants used by the
+// Frobenius maps of Fp6 and Fp12 match the ones derived from the
+// non-residues, and returns an error naming the first mismatching table.
+func ValidateFrobeniusTables() error {
+	c := computeFrobeniusCoeffs()
+	for _, t := range []struct {
+		name        string
+		table, want *Fp2
+	}{
+		{"frob6V1", &Fp2{Fp{}, frob6V1}, &c.v1},
+		{"frob6V2", &Fp2{frob6V2, Fp{}}, &c.v2},
+		{"frob12W1", &frob12W1, &c.w1},
+	} {
+		if t.table.IsEqual(t.want) == 0 {
+			return fmt.Errorf("ff: Frobenius table %v: got %v, want %v", t.name, t.table, t.want)
+		}
+	}
+	return nil
+}
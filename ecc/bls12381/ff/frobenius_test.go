@@ -0,0 +1,93 @@
+package ff
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+func TestFrobeniusTables(t *testing.T) {
+	const testTimes = 1 << 6
+	err := ValidateFrobeniusTables()
+	test.CheckNoErr(t, err, "hardcoded Frobenius tables mismatch")
+
+	// Frob(x) == x^p
+	var got, want Fp12
+	for i := 0; i < testTimes; i++ {
+		x := randomFp12(t)
+		got.Frob(x)
+		want.Exp(x, fpOrder[:])
+		if got.IsEqual(&want) == 0 {
+			test.ReportError(t, got, want, x)
+		}
+	}
+
+	// A corrupted table is detected.
+	saved := frob12W1
+	defer func() { frob12W1 = saved }()
+	frob12W1[0].Neg()
+	err = ValidateFrobeniusTables()
+	test.CheckIsErr(t, err, "corrupted Frobenius table not detected")
+}
+
+func TestFrobeniusPowers(t *testing.T) {
+	const n = 6
+	p := new(big.Int).SetBytes(fpOrder[:])
+	var out [n]Fp12
+	ptrs := make([]*Fp12, n)
+	for i := range ptrs {
+		ptrs[i] = &out[i]
+	}
+	for k := 0; k < 1<<3; k++ {
+		x := randomFp12(t)
+		x.FrobeniusPowers(ptrs)
+
+		// out[i] == x^(p^i)
+		var want Fp12
+		e := big.NewInt(1)
+		for i := range out {
+			want.ExpVarTime(x, e.Bytes())
+			if out[i].IsEqual(&want) == 0 {
+				test.ReportError(t, out[i], want, x, i)
+			}
+			e.Mul(e, p)
+		}
+
+		// x may be one of the outputs.
+		y := *x
+		ptrs[0] = &y
+		y.FrobeniusPowers(ptrs)
+		ptrs[0] = &out[0]
+		if y.IsEqual(x) == 0 || out[n-1].IsEqual(&want) == 0 {
+			test.ReportError(t, y, *x, x)
+		}
+	}
+}
+
+func BenchmarkFrobeniusPowers(b *testing.B) {
+	const n = 6
+	x := randomFp12(b)
+	var out [n]Fp12
+	ptrs := make([]*Fp12, n)
+	exps := make([][]byte, n)
+	p := new(big.Int).SetBytes(fpOrder[:])
+	e := big.NewInt(1)
+	for i := range ptrs {
+		ptrs[i] = &out[i]
+		exps[i] = e.Bytes()
+		e.Mul(e, p)
+	}
+	b.Run("Incremental", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x.FrobeniusPowers(ptrs)
+		}
+	})
+	b.Run("Independent", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := range out {
+				out[j].ExpVarTime(x, exps[j])
+			}
+		}
+	})
+}
@@ -0,0 +1,46 @@
+package ff
+
+import (
+	"crypto"
+	_ "crypto/sha256"
+
+	"github.com/cloudflare/circl/expander"
+)
+
+// HashToFp returns count elements of Fp obtained from msg and the domain
+// separation tag dst, as in hash_to_field of RFC 9380 (Section 5.2) with
+// expand_message_xmd and SHA-256, the parameters used by the BLS12-381
+// suites. Each element is obtained by reducing FpUniformSize bytes of the
+// expanded message.
+func HashToFp(msg, dst []byte, count int) []Fp {
+	const L = FpUniformSize
+	pseudo := expander.NewExpanderMD(crypto.SHA256, dst).Expand(msg, uint(count*L))
+	u := make([]Fp, count)
+	for i := range u {
+		u[i].SetUniformBytes(pseudo[i*L : (i+1)*L])
+	}
+	return u
+}
+
+// SetHash assigns to z the element of Fp obtained from msg and the domain
+// separation tag dst, that is, z = HashToFp(msg, dst, 1)[0].
+func (z *Fp) SetHash(msg, dst []byte) { *z = HashToFp(msg, dst, 1)[0] }
+
+// HashToFp2 returns count elements of Fp2 obtained from msg and the domain
+// separation tag dst, as in hash_to_field of RFC 9380 (Section 5.2) with
+// expand_message_xmd and SHA-256. The coordinates of each element are
+// obtained, in order, from consecutive blocks of FpUniformSize bytes.
+func HashToFp2(msg, dst []byte, count int) []Fp2 {
+	const L = FpUniformSize
+	pseudo := expander.NewExpanderMD(crypto.SHA256, dst).Expand(msg, uint(2*count*L))
+	u := make([]Fp2, count)
+	for i := range u {
+		u[i][0].SetUniformBytes(pseudo[(2*i+0)*L : (2*i+1)*L])
+		u[i][1].SetUniformBytes(pseudo[(2*i+1)*L : (2*i+2)*L])
+	}
+	return u
+}
+
+// SetHash assigns to z the element of Fp2 obtained from msg and the domain
+// separation tag dst, that is, z = HashToFp2(msg, dst, 1)[0].
+func (z *Fp2) SetHash(msg, dst []byte) { *z = HashToFp2(msg, dst, 1)[0] }
@@ -0,0 +1,101 @@
+package ff
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+type vectorHashToField struct {
+	DST     string `json:"dst"`
+	Vectors []struct {
+		Msg string   `json:"msg"`
+		U   []string `json:"u"`
+	} `json:"vectors"`
+}
+
+func readHashToFieldVectors(t *testing.T, fileName string) *vectorHashToField {
+	t.Helper()
+	input, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatalf("File: %v", err)
+	}
+	v := new(vectorHashToField)
+	err = json.Unmarshal(input, v)
+	if err != nil {
+		t.Fatalf("File %v can not be parsed. Error: %v", fileName, err)
+	}
+	return v
+}
+
+func TestHashToField(t *testing.T) {
+	t.Run("Fp", func(t *testing.T) {
+		v := readHashToFieldVectors(t, "../testdata/BLS12381G1_XMD-SHA-256_SSWU_RO_.json")
+		dst := []byte(v.DST)
+		for _, vi := range v.Vectors {
+			msg := []byte(vi.Msg)
+			got := HashToFp(msg, dst, len(vi.U))
+			for i := range vi.U {
+				var want Fp
+				if err := want.SetString(vi.U[i]); err != nil {
+					t.Fatal(err)
+				}
+				if got[i].IsEqual(&want) == 0 {
+					test.ReportError(t, got[i], want, vi.Msg, i)
+				}
+			}
+
+			var z Fp
+			z.SetHash(msg, dst)
+			want := HashToFp(msg, dst, 1)[0]
+			if z.IsEqual(&want) == 0 {
+				test.ReportError(t, z, want, vi.Msg)
+			}
+		}
+	})
+	t.Run("Fp2", func(t *testing.T) {
+		v := readHashToFieldVectors(t, "../testdata/BLS12381G2_XMD-SHA-256_SSWU_RO_.json")
+		dst := []byte(v.DST)
+		for _, vi := range v.Vectors {
+			msg := []byte(vi.Msg)
+			got := HashToFp2(msg, dst, len(vi.U))
+			for i := range vi.U {
+				c := strings.Split(vi.U[i], ",")
+				var want Fp2
+				if err := want.SetString(c[0], c[1]); err != nil {
+					t.Fatal(err)
+				}
+				if got[i].IsEqual(&want) == 0 {
+					test.ReportError(t, got[i], want, vi.Msg, i)
+				}
+			}
+
+			var z Fp2
+			z.SetHash(msg, dst)
+			want := HashToFp2(msg, dst, 1)[0]
+			if z.IsEqual(&want) == 0 {
+				test.ReportError(t, z, want, vi.Msg)
+			}
+		}
+	})
+}
+
+func BenchmarkHashToField(b *testing.B) {
+	msg := []byte("abc")
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_RO_")
+	b.Run("Fp", func(b *testing.B) {
+		var z Fp
+		for i := 0; i < b.N; i++ {
+			z.SetHash(msg, dst)
+		}
+	})
+	b.Run("Fp2", func(b *testing.B) {
+		var z Fp2
+		for i := 0; i < b.N; i++ {
+			z.SetHash(msg, dst)
+		}
+	})
+}
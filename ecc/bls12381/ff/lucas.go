@@ -0,0 +1,67 @@
+package ff
+
+// cycloLucas is the representation of g^n, for an element g = g0 + g1*w of
+// Cyclo6, by the Lucas sequences V_n = g^n + g^-n and U_n, which satisfy
+//
+//	g^n = V_n/2 + U_n*g1*w.
+//
+// Since g^-1 is the conjugate of g, V_n lies in Fp6, and the doubling
+// formulas V_2n = V_n^2 - 2 and U_2n = U_n*V_n only use Fp6 arithmetic. As U_n
+// is kept along V_n, decompression needs no square root and has no sign
+// ambiguity. The exceptional elements g = ±1, where g1 = 0 and g - g^-1 is not
+// invertible, need no special handling, because U_n is defined by its
+// recurrence and the coefficient of w stays zero.
+type cycloLucas struct{ v, u, g1 Fp6 }
+
+// compress sets z to the representation of g^1, i.e., V_1 = 2*g0 and U_1 = 1.
+func (z *cycloLucas) compress(g *Cyclo6) {
+	z.v.Add(&g[0], &g[0])
+	z.u.SetOne()
+	z.g1 = g[1]
+}
+
+// sqr sets z to the representation of g^2n, where z represents g^n.
+func (z *cycloLucas) sqr() {
+	var two Fp6
+	two.SetOne()
+	two.Double()
+	z.u.Mul(&z.u, &z.v)
+	z.v.Sqr(&z.v)
+	z.v.Sub(&z.v, &two)
+}
+
+// decompress sets g to the element of Cyclo6 represented by z.
+func (z *cycloLucas) decompress(g *Cyclo6) {
+	g[0] = z.v
+	g[0].Halve()
+	g[1].Mul(&z.u, &z.g1)
+}
+
+// LucasSqr calculates z = x^2 with one step of the Lucas doubling formulas,
+// i.e., z = (2*x0^2 - 1) + 2*x0*x1*w for x = x0 + x1*w. It requires x to
+// have norm one over Fp6, which holds for every element of Cyclo6.
+func (z *Cyclo6) LucasSqr(x *Cyclo6) {
+	var l cycloLucas
+	l.compress(x)
+	l.sqr()
+	l.decompress(z)
+}
+
+// sqrN calculates z = x^(2^n). If lucas is true, the squarings are computed
+// on the Lucas representation of x, see cycloLucas. Otherwise, the
+// Granger-Scott squaring of Cyclo6.Sqr is used, which is the faster one.
+func (z *Cyclo6) sqrN(x *Cyclo6, n int, lucas bool) {
+	if lucas {
+		var l cycloLucas
+		l.compress(x)
+		for i := 0; i < n; i++ {
+			l.sqr()
+		}
+		l.decompress(z)
+		return
+	}
+	*z = *x
+	for i := 0; i < n; i++ {
+		z.Sqr(z)
+	}
+}
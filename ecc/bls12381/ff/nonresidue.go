@@ -0,0 +1,19 @@
+package ff
+
+// The extension fields are built as the tower
+//
+//	Fp2  = Fp[u]/(u^2 - β),  where β = -1,
+//	Fp6  = Fp2[v]/(v^3 - ξ), where ξ = u+1,
+//	Fp12 = Fp6[w]/(w^2 - v),
+//
+// so that u^2 = β, v^3 = ξ, and w^2 = v. The functions below return these
+// non-residues, e.g., to validate tables of Frobenius coefficients.
+
+// Fp2NonResidue returns β = -1, which is not a square in Fp.
+func Fp2NonResidue() *Fp { z := new(Fp); z.SetOne(); z.Neg(); return z }
+
+// Fp6NonResidue returns ξ = u+1, which is neither a square nor a cube in Fp2.
+func Fp6NonResidue() *Fp2 { z := new(Fp2); z[0].SetOne(); z[1].SetOne(); return z }
+
+// Fp12NonResidue returns v, which is not a square in Fp6.
+func Fp12NonResidue() *Fp6 { z := new(Fp6); z[1].SetOne(); return z }
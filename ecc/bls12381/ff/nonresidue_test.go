@@ -0,0 +1,78 @@
+package ff
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+func TestNonResidues(t *testing.T) {
+	beta, xi, v := Fp2NonResidue(), Fp6NonResidue(), Fp12NonResidue()
+
+	t.Run("tower", func(t *testing.T) {
+		// u^2 = β, v^3 = ξ, and w^2 = v.
+		var u2 Fp2
+		var u Fp2
+		u[1].SetOne()
+		u2.Sqr(&u)
+		want2 := Fp2{*beta, Fp{}}
+		if u2.IsEqual(&want2) == 0 {
+			test.ReportError(t, u2, want2)
+		}
+
+		var v3 Fp6
+		v3.Sqr(v)
+		v3.Mul(&v3, v)
+		want6 := Fp6{*xi, Fp2{}, Fp2{}}
+		if v3.IsEqual(&want6) == 0 {
+			test.ReportError(t, v3, want6)
+		}
+
+		var w, w2 Fp12
+		w[1].SetOne()
+		w2.Sqr(&w)
+		want12 := Fp12{*v, Fp6{}}
+		if w2.IsEqual(&want12) == 0 {
+			test.ReportError(t, w2, want12)
+		}
+	})
+
+	t.Run("beta", func(t *testing.T) {
+		var r Fp
+		test.CheckOk(r.Sqrt(beta) == 0, "β must not be a square in Fp", t)
+	})
+
+	t.Run("xi", func(t *testing.T) {
+		var r Fp2
+		test.CheckOk(r.Sqrt(xi) == 0, "ξ must not be a square in Fp2", t)
+
+		// ξ is a cube iff ξ^((p^2-1)/3) = 1.
+		p := new(big.Int).SetBytes(FpOrder())
+		e := new(big.Int).Mul(p, p)
+		e.Sub(e, big.NewInt(1)).Div(e, big.NewInt(3))
+		var got, one Fp2
+		got.ExpVarTime(xi, e.Bytes())
+		one.SetOne()
+		test.CheckOk(got.IsEqual(&one) == 0, "ξ must not be a cube in Fp2", t)
+	})
+
+	t.Run("v", func(t *testing.T) {
+		// Since [Fp6:Fp2] is odd, v is a square in Fp6 iff its norm, which
+		// is v^(1+p^2+p^4) = ξ, is a square in Fp2.
+		var norm, conj Fp6
+		norm = *v
+		conj = *v
+		for i := 0; i < 2; i++ {
+			conj.Frob(&conj)
+			conj.Frob(&conj)
+			norm.Mul(&norm, &conj)
+		}
+		want := Fp6{*xi, Fp2{}, Fp2{}}
+		if norm.IsEqual(&want) == 0 {
+			test.ReportError(t, norm, want)
+		}
+		var r Fp2
+		test.CheckOk(r.Sqrt(&norm[0]) == 0, "v must not be a square in Fp6", t)
+	})
+}
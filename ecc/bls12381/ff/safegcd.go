@@ -0,0 +1,240 @@
+package ff
+
+import "math/bits"
+
+// Constant-time modular inversion using the safegcd algorithm.
+//
+// References:
+// [1] Bernstein, Yang. "Fast constant-time gcd computation and modular
+// inversion" https://ia.cr/2019/266
+// [2] Wuille. "The safegcd implementation in libsecp256k1 explained"
+// https://github.com/bitcoin-core/secp256k1/blob/master/doc/safegcd_implementation.md
+
+const (
+	// sgLimbs is the number of 62-bit limbs used to hold a 381-bit integer
+	// plus sign and headroom.
+	sgLimbs = 7
+	// sgMask62 selects the lower 62 bits of a word.
+	sgMask62 = (uint64(1) << 62) - 1
+	// sgBatches is the number of batches of 59 divsteps. Theorem 11.2 of [1]
+	// bounds the number of divsteps by floor((49*381+57)/17) = 1101 for
+	// 381-bit inputs, so 19*59 = 1121 divsteps are enough.
+	sgBatches = 19
+)
+
+// signed62 represents the integer sum(v[i]*2^(62*i)), where all limbs but
+// the last one are in [0,2^62) once normalized.
+type signed62 [sgLimbs]int64
+
+// trans2x2 is a transition matrix scaled by 2^62.
+type trans2x2 struct{ u, v, q, r int64 }
+
+// int128 is a signed 128-bit integer in two's complement.
+type int128 struct{ hi, lo uint64 }
+
+func (z *int128) mul(a, b int64) {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	hi -= uint64(a>>63) & uint64(b)
+	hi -= uint64(b>>63) & uint64(a)
+	z.hi, z.lo = hi, lo
+}
+
+func (z *int128) accMul(a, b int64) {
+	var t int128
+	var c uint64
+	t.mul(a, b)
+	z.lo, c = bits.Add64(z.lo, t.lo, 0)
+	z.hi, _ = bits.Add64(z.hi, t.hi, c)
+}
+
+func (z *int128) rsh62() {
+	z.lo = (z.lo >> 62) | (z.hi << 2)
+	z.hi = uint64(int64(z.hi) >> 62)
+}
+
+// safegcdModInfo contains the modulus in signed62 form and its inverse
+// modulo 2^62.
+type safegcdModInfo struct {
+	modulus signed62
+	inv62   uint64
+}
+
+// fpModInfo is the modulus information for FpOrder.
+var fpModInfo = safegcdModInfo{
+	modulus: signed62{
+		0x39feffffffffaaab, 0x3aaffffac54ffffe, 0x330d2a0f6b0f6241,
+		0x1dd2e13ce144afd9, 0x1ba7b6434bacd764, 0x0447a8e5ff9a692c,
+		0x00000000000001a0,
+	},
+	inv62: 0x360c000300030003,
+}
+
+func (s *signed62) fromUint64Le(x []uint64) {
+	for i := range s {
+		w, off := (62*i)/64, uint((62*i)%64)
+		var l uint64
+		if w < len(x) {
+			l = x[w] >> off
+		}
+		if w+1 < len(x) {
+			l |= x[w+1] << (64 - off)
+		}
+		s[i] = int64(l & sgMask62)
+	}
+}
+
+// toUint64Le assumes s is normalized and non-negative.
+func (s *signed62) toUint64Le(x []uint64) {
+	for i := range x {
+		x[i] = 0
+	}
+	for i, l := range s {
+		w, off := (62*i)/64, uint((62*i)%64)
+		if w < len(x) {
+			x[w] |= uint64(l) << off
+		}
+		if w+1 < len(x) {
+			x[w+1] |= uint64(l) >> (64 - off)
+		}
+	}
+}
+
+// divsteps59 performs 59 divsteps on the lower bits of f and g, and returns
+// the updated eta=-delta. The transition matrix, scaled by 2^62, is stored in
+// t. Runs in constant time.
+func divsteps59(eta int64, f0, g0 uint64, t *trans2x2) int64 {
+	u, v, q, r := uint64(8), uint64(0), uint64(0), uint64(8)
+	f, g := f0, g0
+	for i := 3; i < 62; i++ {
+		// c1 is a mask for delta > 0, and c2 is a mask for g odd.
+		c1 := uint64(eta >> 63)
+		c2 := -(g & 1)
+		x := (f ^ c1) - c1
+		y := (u ^ c1) - c1
+		z := (v ^ c1) - c1
+		g += x & c2
+		q += y & c2
+		r += z & c2
+		c1 &= c2
+		// eta becomes -eta-1 if swapping, and eta-1 otherwise.
+		eta = (eta ^ int64(c1)) - 1 - int64(c1)
+		f += g & c1
+		u += q & c1
+		v += r & c1
+		g >>= 1
+		u <<= 1
+		v <<= 1
+	}
+	t.u, t.v, t.q, t.r = int64(u), int64(v), int64(q), int64(r)
+	return eta
+}
+
+// updateDE computes (d,e) = t*(d,e)/2^62 mod modulus. The inputs must be in
+// the range (-2*modulus, modulus), and so are the outputs.
+func (m *safegcdModInfo) updateDE(d, e *signed62, t *trans2x2) {
+	var cd, ce int128
+	u, v, q, r := t.u, t.v, t.q, t.r
+
+	// [md,me] start as zero, plus [u,q] if d<0, plus [v,r] if e<0.
+	sd, se := d[sgLimbs-1]>>63, e[sgLimbs-1]>>63
+	md := (u & sd) + (v & se)
+	me := (q & sd) + (r & se)
+
+	cd.mul(u, d[0])
+	cd.accMul(v, e[0])
+	ce.mul(q, d[0])
+	ce.accMul(r, e[0])
+
+	// Correct md and me so that t*[d,e]+modulus*[md,me] is divisible by 2^62.
+	md -= int64((m.inv62*cd.lo + uint64(md)) & sgMask62)
+	me -= int64((m.inv62*ce.lo + uint64(me)) & sgMask62)
+	cd.accMul(m.modulus[0], md)
+	ce.accMul(m.modulus[0], me)
+	cd.rsh62()
+	ce.rsh62()
+
+	for i := 1; i < sgLimbs; i++ {
+		cd.accMul(u, d[i])
+		cd.accMul(v, e[i])
+		cd.accMul(m.modulus[i], md)
+		ce.accMul(q, d[i])
+		ce.accMul(r, e[i])
+		ce.accMul(m.modulus[i], me)
+		d[i-1] = int64(cd.lo & sgMask62)
+		e[i-1] = int64(ce.lo & sgMask62)
+		cd.rsh62()
+		ce.rsh62()
+	}
+	d[sgLimbs-1] = int64(cd.lo)
+	e[sgLimbs-1] = int64(ce.lo)
+}
+
+// updateFG computes (f,g) = t*(f,g)/2^62.
+func updateFG(f, g *signed62, t *trans2x2) {
+	var cf, cg int128
+	u, v, q, r := t.u, t.v, t.q, t.r
+
+	cf.mul(u, f[0])
+	cf.accMul(v, g[0])
+	cg.mul(q, f[0])
+	cg.accMul(r, g[0])
+	cf.rsh62()
+	cg.rsh62()
+
+	for i := 1; i < sgLimbs; i++ {
+		cf.accMul(u, f[i])
+		cf.accMul(v, g[i])
+		cg.accMul(q, f[i])
+		cg.accMul(r, g[i])
+		f[i-1] = int64(cf.lo & sgMask62)
+		g[i-1] = int64(cg.lo & sgMask62)
+		cf.rsh62()
+		cg.rsh62()
+	}
+	f[sgLimbs-1] = int64(cf.lo)
+	g[sgLimbs-1] = int64(cg.lo)
+}
+
+// normalize takes r in the range (-2*modulus, modulus), negates it if sign
+// is negative, and reduces it to the range [0, modulus).
+func (m *safegcdModInfo) normalize(r *signed62, sign int64) {
+	condAdd := r[sgLimbs-1] >> 63
+	condNeg := sign >> 63
+	for i := range r {
+		r[i] += m.modulus[i] & condAdd
+		r[i] = (r[i] ^ condNeg) - condNeg
+	}
+	m.carry(r)
+
+	condAdd = r[sgLimbs-1] >> 63
+	for i := range r {
+		r[i] += m.modulus[i] & condAdd
+	}
+	m.carry(r)
+}
+
+// carry propagates the top bits of each limb to bring them back to the
+// range [0,2^62).
+func (m *safegcdModInfo) carry(r *signed62) {
+	for i := 0; i < sgLimbs-1; i++ {
+		r[i+1] += r[i] >> 62
+		r[i] &= int64(sgMask62)
+	}
+}
+
+// inv sets x = x^-1 mod modulus, or x = 0 when x is zero. The input must be
+// in the range [0, modulus). Runs in constant time.
+func (m *safegcdModInfo) inv(x *signed62) {
+	d, e := signed62{}, signed62{1}
+	f, g := m.modulus, *x
+	eta := int64(-1) // eta = -delta, and delta starts at 1.
+	var t trans2x2
+	for i := 0; i < sgBatches; i++ {
+		eta = divsteps59(eta, uint64(f[0]), uint64(g[0]), &t)
+		m.updateDE(&d, &e, &t)
+		updateFG(&f, &g, &t)
+	}
+	// At this point g=0 and f=±1, unless x=0 in which case d=0.
+	m.normalize(&d, f[sgLimbs-1])
+	*x = d
+}
@@ -76,7 +76,7 @@ func (z *Scalar) MarshalBinary() ([]byte, error) {
 // to ScalarOrder-1.
 func (z *Scalar) UnmarshalBinary(data []byte) error {
 	if len(data) < ScalarSize {
-		return errInputLength
+		return decodeError("Scalar", ErrWrongLength)
 	}
 	in64, err := setBytesBounded(data[:ScalarSize], scOrder[:])
 	if err == nil {
@@ -84,7 +84,7 @@ func (z *Scalar) UnmarshalBinary(data []byte) error {
 		copy(s[:], in64[:ScalarSize/8])
 		z.toMont(s)
 	}
-	return err
+	return decodeError("Scalar", err)
 }
 
 // SetString reconstructs a Fp from a numeric string from 0 to ScalarOrder-1.
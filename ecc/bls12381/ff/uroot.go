@@ -8,7 +8,7 @@ const URootSize = Fp12Size
 type URoot Cyclo6
 
 func (z URoot) String() string                  { return (Cyclo6)(z).String() }
-func (z *URoot) UnmarshalBinary(b []byte) error { return (*Fp12)(z).UnmarshalBinary(b) }
+func (z *URoot) UnmarshalBinary(b []byte) error { return z.decode(b) }
 func (z URoot) MarshalBinary() ([]byte, error)  { return (Fp12)(z).MarshalBinary() }
 func (z *URoot) SetIdentity()                   { (*Fp12)(z).SetOne() }
 func (z URoot) IsEqual(x *URoot) int            { return (Cyclo6)(z).IsEqual((*Cyclo6)(x)) }
@@ -17,3 +17,29 @@ func (z *URoot) Exp(x *URoot, n []byte)         { (*Cyclo6)(z).exp((*Cyclo6)(x),
 func (z *URoot) Mul(x, y *URoot)                { (*Cyclo6)(z).Mul((*Cyclo6)(x), (*Cyclo6)(y)) }
 func (z *URoot) Sqr(x *URoot)                   { (*Cyclo6)(z).Sqr((*Cyclo6)(x)) }
 func (z *URoot) Inv(x *URoot)                   { (*Cyclo6)(z).Inv((*Cyclo6)(x)) }
+
+// decode reconstructs a URoot from a slice that must have at least URootSize
+// bytes. It returns ErrNotInSubgroup if the element is not an n-th root of
+// unit, in which case z is not modified.
+func (z *URoot) decode(b []byte) error {
+	var x Cyclo6
+	if err := x.UnmarshalBinary(b); err != nil {
+		return err
+	}
+	if x.isURoot() == 0 {
+		return decodeError("URoot", ErrNotInSubgroup)
+	}
+	*z = (URoot)(x)
+	return nil
+}
+
+// isURoot returns 1 if x is an n-th root of unit; otherwise returns 0. Since
+// p = paramX mod n, the roots satisfy x^p = x^paramX, and for BLS12-381 the
+// converse holds for the elements of Cyclo6 [Scott, ia.cr/2021/1130], so
+// one Frobenius map replaces the exponentiation by n.
+func (x *Cyclo6) isURoot() int {
+	var xp, xz Cyclo6
+	xp.Frob(x)
+	xz.PowToX(x)
+	return xp.IsEqual(&xz)
+}
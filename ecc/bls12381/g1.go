@@ -1,14 +1,11 @@
 package bls12381
 
 import (
-	"crypto"
-	_ "crypto/sha256"
 	"crypto/subtle"
 	"fmt"
-	"math/big"
+	"sync"
 
 	"github.com/cloudflare/circl/ecc/bls12381/ff"
-	"github.com/cloudflare/circl/expander"
 )
 
 // G1Size is the length in bytes of an element in G1 in uncompressed form..
@@ -49,6 +46,9 @@ func (g *G1) SetBytes(b []byte) error {
 		if isCompressed == 1 {
 			l = G1SizeCompressed
 		}
+		if len(b) < l {
+			return errInputLength
+		}
 		zeros := make([]byte, l-1)
 		if (b[0]&0x1F) != 0 || subtle.ConstantTimeCompare(b[1:l], zeros) != 1 {
 			return errEncoding
@@ -170,7 +170,8 @@ func (g *G1) isRTorsion() bool {
 	return Q.IsIdentity()
 }
 
-// clearCofactor maps g to a point in the r-torsion subgroup.
+// ClearCofactor maps g to a point in the r-torsion subgroup, and it is
+// faster than a scalar multiplication by the cofactor.
 //
 // This method multiplies g times (1-z) rather than (z-1)^2/3, where z is the
 // BLS12 parameter. This is enough to remove points of order
@@ -180,7 +181,7 @@ func (g *G1) isRTorsion() bool {
 // and because there are no points of order h^2. See Section 5 of Wahby-Boneh
 // "Fast and simple constant-time hashing to the BLS12-381 elliptic curve" at
 // https://eprint.iacr.org/2019/403
-func (g *G1) clearCofactor() { g.scalarMultShort(bls12381.oneMinusZ[:], g) }
+func (g *G1) ClearCofactor() { g.scalarMultShort(bls12381.oneMinusZ[:], g) }
 
 // Double updates g = 2g.
 func (g *G1) Double() {
@@ -263,7 +264,7 @@ func (g *G1) Add(P, Q *G1) {
 }
 
 // ScalarMult calculates g = kP.
-func (g *G1) ScalarMult(k *Scalar, P *G1) { b, _ := k.MarshalBinary(); g.scalarMult(b, P) }
+func (g *G1) ScalarMult(k *Scalar, P *G1) { g.scalarMultGLV(k, P) }
 
 // scalarMult calculates g = kP, where k is the scalar in big-endian order.
 func (g *G1) scalarMult(k []byte, P *G1) {
@@ -293,6 +294,46 @@ func (g *G1) scalarMult(k []byte, P *G1) {
 	*g = Q
 }
 
+// g1BaseTable holds [j*16^i]G1Generator() for i in [0, 2*ScalarSize) and
+// j in [0, 16). It is computed on the first call to ScalarBaseMult.
+var (
+	g1BaseOnce  sync.Once
+	g1BaseTable [2 * ScalarSize][16]G1
+)
+
+func initG1BaseTable() {
+	P := *G1Generator()
+	for i := range g1BaseTable {
+		g1BaseTable[i][0].SetIdentity()
+		g1BaseTable[i][1] = P
+		for j := 2; j < 16; j++ {
+			g1BaseTable[i][j].Add(&g1BaseTable[i][j-1], &P)
+		}
+		P.Double()
+		P.Double()
+		P.Double()
+		P.Double()
+	}
+}
+
+// ScalarBaseMult calculates g = kG, where G is the generator of G1. It runs
+// in constant time, and uses a precomputed table of about 150 KB, so it
+// needs no doublings, and is faster than ScalarMult(k, G1Generator()).
+func (g *G1) ScalarBaseMult(k *Scalar) {
+	g1BaseOnce.Do(initG1BaseTable)
+	b, _ := k.MarshalBinary()
+	var Q, T G1
+	Q.SetIdentity()
+	for i := range g1BaseTable {
+		idx := 0xf & (b[len(b)-1-i/2] >> uint(4*(i%2)))
+		for j := range g1BaseTable[i] {
+			T.cmov(&g1BaseTable[i][j], subtle.ConstantTimeByteEq(idx, uint8(j)))
+		}
+		Q.Add(&Q, &T)
+	}
+	*g = Q
+}
+
 // scalarMultShort multiplies by a short, constant scalar k, where k is the
 // scalar in big-endian order. Runtime depends on the scalar.
 func (g *G1) scalarMultShort(k []byte, P *G1) {
@@ -352,40 +393,29 @@ func (g *G1) toAffine() {
 // an optional domain separation tag) to elements in G1. This function must not
 // be used as a hash function, otherwise use G1.Hash instead.
 func (g *G1) Encode(input, dst []byte) {
-	const L = 64
-	pseudo := expander.NewExpanderMD(crypto.SHA256, dst).Expand(input, L)
-
-	bu := new(big.Int).SetBytes(pseudo)
-	bu.Mod(bu, new(big.Int).SetBytes(ff.FpOrder()))
-
 	var u ff.Fp
-	u.SetBytes(pseudo[:L])
+	u.SetHash(input, dst)
 
 	var q isogG1Point
 	q.sswu(&u)
 	g.evalIsogG1(&q)
-	g.clearCofactor()
+	g.ClearCofactor()
 }
 
 // Hash produces an element of G1 from the hash of an input byte string and
 // an optional domain separation tag. This function is safe to use when a
 // random oracle returning points in G1 be required.
 func (g *G1) Hash(input, dst []byte) {
-	const L = 64
-	pseudo := expander.NewExpanderMD(crypto.SHA256, dst).Expand(input, 2*L)
-
-	var u0, u1 ff.Fp
-	u0.SetBytes(pseudo[0*L : 1*L])
-	u1.SetBytes(pseudo[1*L : 2*L])
+	u := ff.HashToFp(input, dst, 2)
 
 	var q0, q1 isogG1Point
-	q0.sswu(&u0)
-	q1.sswu(&u1)
+	q0.sswu(&u[0])
+	q1.sswu(&u[1])
 	var p0, p1 G1
 	p0.evalIsogG1(&q0)
 	p1.evalIsogG1(&q1)
 	g.Add(&p0, &p1)
-	g.clearCofactor()
+	g.ClearCofactor()
 }
 
 // G1Generator returns the generator point of G1.
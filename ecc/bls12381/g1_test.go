@@ -26,7 +26,7 @@ func randomG1(t testing.TB) *G1 {
 
 	r.sswu(u)
 	P.evalIsogG1(r)
-	P.clearCofactor()
+	P.ClearCofactor()
 	got := P.IsOnG1()
 	want := true
 
@@ -71,6 +71,25 @@ func TestG1ScalarMult(t *testing.T) {
 	}
 }
 
+func TestG1ScalarBaseMult(t *testing.T) {
+	const testTimes = 1 << 6
+	var got, want, ref G1
+	G := G1Generator()
+	for i := 0; i <= testTimes; i++ {
+		k := new(Scalar)
+		if i > 0 {
+			k = randomScalar(t)
+		}
+		b, _ := k.MarshalBinary()
+		got.ScalarBaseMult(k)
+		want.ScalarMult(k, G)
+		ref.scalarMultShort(b, G)
+		if !got.IsEqual(&want) || !got.IsEqual(&ref) {
+			test.ReportError(t, got, want, k)
+		}
+	}
+}
+
 func TestG1Hash(t *testing.T) {
 	const testTimes = 1 << 8
 
@@ -117,11 +136,50 @@ func BenchmarkG1(b *testing.B) {
 			P.ScalarMult(k, P)
 		}
 	})
+	b.Run("BaseMul", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			P.ScalarBaseMult(k)
+		}
+	})
 	b.Run("Hash", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			P.Hash(msg[:], dst[:])
 		}
 	})
+	b.Run("ClearCofactor", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			P.ClearCofactor()
+		}
+	})
+}
+
+func TestG1ClearCofactor(t *testing.T) {
+	const testTimes = 1 << 6
+	// The effective cofactor of G1 is 1-z.
+	hEff := bls12381.oneMinusZ[:]
+	for i := 0; i < testTimes; i++ {
+		u := &ff.Fp{}
+		err := u.Random(rand.Reader)
+		test.CheckNoErr(t, err, "random fp")
+		q := &isogG1Point{}
+		q.sswu(u)
+		P := &G1{}
+		P.evalIsogG1(q)
+
+		got := *P
+		got.ClearCofactor()
+		want := &G1{}
+		want.scalarMult(hEff, P)
+		if !got.IsEqual(want) {
+			test.ReportError(t, got, want, u)
+		}
+
+		rP := &G1{}
+		rP.scalarMult(Order(), &got)
+		if !got.IsOnG1() || !rP.IsIdentity() {
+			test.ReportError(t, got.IsOnG1(), true, u)
+		}
+	}
 }
 
 func TestG1Serial(t *testing.T) {
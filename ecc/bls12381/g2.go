@@ -1,12 +1,11 @@
 package bls12381
 
 import (
-	"crypto"
 	"crypto/subtle"
 	"fmt"
+	"sync"
 
 	"github.com/cloudflare/circl/ecc/bls12381/ff"
-	"github.com/cloudflare/circl/expander"
 )
 
 // G2Size is the length in bytes of an element in G2 in uncompressed form..
@@ -47,6 +46,9 @@ func (g *G2) SetBytes(b []byte) error {
 		if isCompressed == 1 {
 			l = G2SizeCompressed
 		}
+		if len(b) < l {
+			return errInputLength
+		}
 		zeros := make([]byte, l-1)
 		if (b[0]&0x1F) != 0 || subtle.ConstantTimeCompare(b[1:l], zeros) != 1 {
 			return errEncoding
@@ -164,7 +166,8 @@ func (g *G2) psi() {
 	g.y.Mul(&g2Psi.beta, &g.y)
 }
 
-// clearCofactor maps g to a point in the r-torsion subgroup.
+// ClearCofactor maps g to a point in the r-torsion subgroup, and it is
+// faster than a scalar multiplication by the cofactor.
 //
 // This method multiplies g times a multiple of the cofactor as proposed by
 // Fuentes-Knapp-Rodríguez at https://doi.org/10.1007/978-3-642-28496-0_25.
@@ -173,7 +176,7 @@ func (g *G2) psi() {
 // "Efficient hash maps to G2 on BLS curves" at https://eprint.iacr.org/2017/419
 //
 //	h(a)P = [x^2-x-1]P + [x-1]ψ(P) + ψ^2(2P)
-func (g *G2) clearCofactor() {
+func (g *G2) ClearCofactor() {
 	x := bls12381.minusZ[:]
 	xP, psiP := &G2{}, &G2{}
 	_2P := *g
@@ -199,7 +202,7 @@ func (g *G2) Double() { doubleAndLine(g, nil) }
 func (g *G2) Add(P, Q *G2) { addAndLine(g, P, Q, nil) }
 
 // ScalarMult calculates g = kP.
-func (g *G2) ScalarMult(k *Scalar, P *G2) { b, _ := k.MarshalBinary(); g.scalarMult(b, P) }
+func (g *G2) ScalarMult(k *Scalar, P *G2) { g.scalarMultGLV(k, P) }
 
 // scalarMult calculates g = kP, where k is the scalar in big-endian order.
 func (g *G2) scalarMult(k []byte, P *G2) {
@@ -229,6 +232,46 @@ func (g *G2) scalarMult(k []byte, P *G2) {
 	*g = Q
 }
 
+// g2BaseTable holds [j*16^i]G2Generator() for i in [0, 2*ScalarSize) and
+// j in [0, 16). It is computed on the first call to ScalarBaseMult.
+var (
+	g2BaseOnce  sync.Once
+	g2BaseTable [2 * ScalarSize][16]G2
+)
+
+func initG2BaseTable() {
+	P := *G2Generator()
+	for i := range g2BaseTable {
+		g2BaseTable[i][0].SetIdentity()
+		g2BaseTable[i][1] = P
+		for j := 2; j < 16; j++ {
+			g2BaseTable[i][j].Add(&g2BaseTable[i][j-1], &P)
+		}
+		P.Double()
+		P.Double()
+		P.Double()
+		P.Double()
+	}
+}
+
+// ScalarBaseMult calculates g = kG, where G is the generator of G2. It runs
+// in constant time, and uses a precomputed table of about 300 KB, so it
+// needs no doublings, and is faster than ScalarMult(k, G2Generator()).
+func (g *G2) ScalarBaseMult(k *Scalar) {
+	g2BaseOnce.Do(initG2BaseTable)
+	b, _ := k.MarshalBinary()
+	var Q, T G2
+	Q.SetIdentity()
+	for i := range g2BaseTable {
+		idx := 0xf & (b[len(b)-1-i/2] >> uint(4*(i%2)))
+		for j := range g2BaseTable[i] {
+			T.cmov(&g2BaseTable[i][j], subtle.ConstantTimeByteEq(idx, uint8(j)))
+		}
+		Q.Add(&Q, &T)
+	}
+	*g = Q
+}
+
 // scalarMultShort multiplies by a short, constant scalar k, where k is the
 // scalar in big-endian order. Runtime depends on the scalar.
 func (g *G2) scalarMultShort(k []byte, P *G2) {
@@ -262,40 +305,29 @@ func (g *G2) IsEqual(p *G2) bool {
 // an optional domain separation tag) to elements in G2. This function must not
 // be used as a hash function, otherwise use G2.Hash instead.
 func (g *G2) Encode(input, dst []byte) {
-	const L = 64
-	pseudo := expander.NewExpanderMD(crypto.SHA256, dst).Expand(input, 2*L)
-
 	var u ff.Fp2
-	u[0].SetBytes(pseudo[0*L : 1*L])
-	u[1].SetBytes(pseudo[1*L : 2*L])
+	u.SetHash(input, dst)
 
 	var q isogG2Point
 	q.sswu(&u)
 	g.evalIsogG2(&q)
-	g.clearCofactor()
+	g.ClearCofactor()
 }
 
 // Hash produces an element of G2 from the hash of an input byte string and
 // an optional domain separation tag. This function is safe to use when a
 // random oracle returning points in G2 be required.
 func (g *G2) Hash(input, dst []byte) {
-	const L = 64
-	pseudo := expander.NewExpanderMD(crypto.SHA256, dst).Expand(input, 4*L)
-
-	var u0, u1 ff.Fp2
-	u0[0].SetBytes(pseudo[0*L : 1*L])
-	u0[1].SetBytes(pseudo[1*L : 2*L])
-	u1[0].SetBytes(pseudo[2*L : 3*L])
-	u1[1].SetBytes(pseudo[3*L : 4*L])
+	u := ff.HashToFp2(input, dst, 2)
 
 	var q0, q1 isogG2Point
-	q0.sswu(&u0)
-	q1.sswu(&u1)
+	q0.sswu(&u[0])
+	q1.sswu(&u[1])
 	var p0, p1 G2
 	p0.evalIsogG2(&q0)
 	p1.evalIsogG2(&q1)
 	g.Add(&p0, &p1)
-	g.clearCofactor()
+	g.ClearCofactor()
 }
 
 // isOnCurve returns true if g is a valid point on the curve.
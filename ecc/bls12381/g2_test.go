@@ -2,6 +2,7 @@ package bls12381
 
 import (
 	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"testing"
 
@@ -55,6 +56,25 @@ func TestG2ScalarMult(t *testing.T) {
 	}
 }
 
+func TestG2ScalarBaseMult(t *testing.T) {
+	const testTimes = 1 << 6
+	var got, want, ref G2
+	G := G2Generator()
+	for i := 0; i <= testTimes; i++ {
+		k := new(Scalar)
+		if i > 0 {
+			k = randomScalar(t)
+		}
+		b, _ := k.MarshalBinary()
+		got.ScalarBaseMult(k)
+		want.ScalarMult(k, G)
+		ref.scalarMultShort(b, G)
+		if !got.IsEqual(&want) || !got.IsEqual(&ref) {
+			test.ReportError(t, got, want, k)
+		}
+	}
+}
+
 func TestG2Hash(t *testing.T) {
 	const testTimes = 1 << 8
 
@@ -199,11 +219,50 @@ func BenchmarkG2(b *testing.B) {
 			P.ScalarMult(k, P)
 		}
 	})
+	b.Run("BaseMul", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			P.ScalarBaseMult(k)
+		}
+	})
 	b.Run("Hash", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			P.Hash(msg[:], dst[:])
 		}
 	})
+	b.Run("ClearCofactor", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			P.ClearCofactor()
+		}
+	})
+}
+
+func TestG2ClearCofactor(t *testing.T) {
+	const testTimes = 1 << 5
+	// The effective cofactor of G2, see Section 8.8.2 of RFC 9380.
+	hEff, _ := hex.DecodeString("0bc69f08f2ee75b3584c6a0ea91b352888e2a8e9145ad7689986ff031508ffe1329c2f178731db956d82bf015d1212b02ec0ec69d7477c1ae954cbc06689f6a359894c0adebbf6b4e8020005aaa95551")
+	for i := 0; i < testTimes; i++ {
+		u := &ff.Fp2{}
+		test.CheckNoErr(t, u[0].Random(rand.Reader), "random fp")
+		test.CheckNoErr(t, u[1].Random(rand.Reader), "random fp")
+		q := &isogG2Point{}
+		q.sswu(u)
+		P := &G2{}
+		P.evalIsogG2(q)
+
+		got := *P
+		got.ClearCofactor()
+		want := &G2{}
+		want.scalarMult(hEff, P)
+		if !got.IsEqual(want) {
+			test.ReportError(t, got, want, u)
+		}
+
+		rP := &G2{}
+		rP.scalarMult(Order(), &got)
+		if !got.IsOnG2() || !rP.IsIdentity() {
+			test.ReportError(t, got.IsOnG2(), true, u)
+		}
+	}
 }
 
 func TestG2Torsion(t *testing.T) {
@@ -0,0 +1,147 @@
+package bls12381
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"math/bits"
+)
+
+// The scalar multiplications use endomorphisms φ of G1 and G2 acting as
+// multiplication by z^2, where z is the BLS12 parameter; see Galbraith-Lin-
+// Scott "Endomorphisms for faster elliptic curve cryptography on a large
+// class of curves" at https://eprint.iacr.org/2008/194. As the group order is
+// r = z^4 - z^2 + 1, writing k = k1 + k2*z^2 with 0 <= k1 < z^2 gives
+// k2 < z^2, so [k]P = [k1]P + [k2]φ(P) with 128-bit scalars k1 and k2, which
+// halves the number of doublings.
+var (
+	// glvZ2 is z^2 (little-endian words).
+	glvZ2 = [2]uint64{0x0000000100000000, 0xac45a4010001a402}
+	// glvZ2Inv is floor(2^256/z^2) (little-endian words).
+	glvZ2Inv = [3]uint64{0x63f6e522f6cfee2e, 0x7c6becf1e01faadd, 0x1}
+)
+
+// glvSplit returns k1 and k2 (as little-endian words) such that
+// k = k1 + k2*z^2 and 0 <= k1 < z^2. It runs in constant time.
+func glvSplit(k *Scalar) (k1, k2 [2]uint64) {
+	b, _ := k.MarshalBinary()
+	var kw [4]uint64
+	for i := range kw {
+		kw[i] = binary.BigEndian.Uint64(b[len(b)-8*(i+1):])
+	}
+
+	// Barrett's estimate of the quotient is at most 2 less than k2.
+	var kq [7]uint64
+	mulWords(kq[:], kw[:], glvZ2Inv[:])
+	k2 = [2]uint64{kq[4], kq[5]}
+	var qz [4]uint64
+	mulWords(qz[:], k2[:], glvZ2[:])
+	var r, s [4]uint64
+	var c uint64
+	for i := range r {
+		r[i], c = bits.Sub64(kw[i], qz[i], c)
+	}
+	for j := 0; j < 2; j++ {
+		s[0], c = bits.Sub64(r[0], glvZ2[0], 0)
+		s[1], c = bits.Sub64(r[1], glvZ2[1], c)
+		s[2], c = bits.Sub64(r[2], 0, c)
+		s[3], c = bits.Sub64(r[3], 0, c)
+		// If r >= z^2, then r = r - z^2 and k2 = k2 + 1.
+		for i := range r {
+			cselectU64(&r[i], 1-c, r[i], s[i])
+		}
+		k2[0], c = bits.Add64(k2[0], 1-c, 0)
+		k2[1] += c
+	}
+	return [2]uint64{r[0], r[1]}, k2
+}
+
+// mulWords sets z = x*y, where z has len(x)+len(y) words initialized to zero.
+func mulWords(z, x, y []uint64) {
+	for i := range x {
+		var carry uint64
+		for j := range y {
+			hi, lo := bits.Mul64(x[i], y[j])
+			var c uint64
+			lo, c = bits.Add64(lo, z[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			z[i+j], carry = lo, hi
+		}
+		z[i+len(y)] = carry
+	}
+}
+
+func cselectU64(z *uint64, b, x, y uint64) { *z = (x &^ (-b)) | (y & (-b)) }
+
+// glvIndex returns the i-th 2-bit digits of k1 and k2 as an index into the
+// tables of scalarMultGLV.
+func glvIndex(k1, k2 *[2]uint64, i int) uint8 {
+	return uint8((k1[i/64]>>uint(i%64))&3 | ((k2[i/64]>>uint(i%64))&3)<<2)
+}
+
+// phi sets g = φ(P) = -σ^2(P) = [z^2]P.
+func (g *G1) phi(P *G1) { g.sigma2(P); g.Neg() }
+
+// scalarMultGLV calculates g = kP with the GLV method. It runs in constant
+// time.
+func (g *G1) scalarMultGLV(k *Scalar, P *G1) {
+	k1, k2 := glvSplit(k)
+	var T [16]G1 // T[a+4b] = [a]P + [b]φ(P)
+	var phiP G1
+	phiP.phi(P)
+	T[0].SetIdentity()
+	T[1] = *P
+	T[2] = *P
+	T[2].Double()
+	T[3].Add(&T[2], P)
+	for i := 4; i < 16; i++ {
+		T[i].Add(&T[i-4], &phiP)
+	}
+
+	var Q, R G1
+	Q.SetIdentity()
+	for i := 126; i >= 0; i -= 2 {
+		Q.Double()
+		Q.Double()
+		idx := glvIndex(&k1, &k2, i)
+		for j := range T {
+			R.cmov(&T[j], subtle.ConstantTimeByteEq(idx, uint8(j)))
+		}
+		Q.Add(&Q, &R)
+	}
+	*g = Q
+}
+
+// phi sets g = φ(P) = ψ^2(P) = [z^2]P.
+func (g *G2) phi(P *G2) { *g = *P; g.psi(); g.psi() }
+
+// scalarMultGLV calculates g = kP with the GLS method, the analogue of GLV
+// for the endomorphism ψ^2. It runs in constant time.
+func (g *G2) scalarMultGLV(k *Scalar, P *G2) {
+	k1, k2 := glvSplit(k)
+	var T [16]G2 // T[a+4b] = [a]P + [b]φ(P)
+	var phiP G2
+	phiP.phi(P)
+	T[0].SetIdentity()
+	T[1] = *P
+	T[2] = *P
+	T[2].Double()
+	T[3].Add(&T[2], P)
+	for i := 4; i < 16; i++ {
+		T[i].Add(&T[i-4], &phiP)
+	}
+
+	var Q, R G2
+	Q.SetIdentity()
+	for i := 126; i >= 0; i -= 2 {
+		Q.Double()
+		Q.Double()
+		idx := glvIndex(&k1, &k2, i)
+		for j := range T {
+			R.cmov(&T[j], subtle.ConstantTimeByteEq(idx, uint8(j)))
+		}
+		Q.Add(&Q, &R)
+	}
+	*g = Q
+}
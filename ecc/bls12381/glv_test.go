@@ -0,0 +1,90 @@
+package bls12381
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+func TestGLV(t *testing.T) {
+	const testTimes = 1 << 7
+	t.Run("split", func(t *testing.T) {
+		z2 := new(big.Int).SetUint64(0xd201000000010000)
+		z2.Mul(z2, z2)
+		toInt := func(x [2]uint64) *big.Int {
+			v := new(big.Int).SetUint64(x[1])
+			return v.Lsh(v, 64).Add(v, new(big.Int).SetUint64(x[0]))
+		}
+		var minusOne Scalar
+		minusOne.SetOne()
+		minusOne.Neg()
+		for i := 0; i <= testTimes+1; i++ {
+			k := randomScalar(t)
+			if i == testTimes {
+				k = new(Scalar)
+			} else if i == testTimes+1 {
+				k = &minusOne
+			}
+			b, _ := k.MarshalBinary()
+			want2, want1 := new(big.Int).QuoRem(new(big.Int).SetBytes(b), z2, new(big.Int))
+			k1, k2 := glvSplit(k)
+			if toInt(k1).Cmp(want1) != 0 || toInt(k2).Cmp(want2) != 0 {
+				test.ReportError(t, k1, want1, k)
+				test.ReportError(t, k2, want2, k)
+			}
+		}
+	})
+	t.Run("G1", func(t *testing.T) {
+		var got, want G1
+		for i := 0; i < testTimes; i++ {
+			P := randomG1(t)
+			k := randomScalar(t)
+			b, _ := k.MarshalBinary()
+			got.scalarMultGLV(k, P)
+			want.scalarMult(b, P)
+			if !got.IsEqual(&want) {
+				test.ReportError(t, got, want, P, k)
+			}
+		}
+	})
+	t.Run("G2", func(t *testing.T) {
+		var got, want G2
+		for i := 0; i < testTimes; i++ {
+			P := randomG2(t)
+			k := randomScalar(t)
+			b, _ := k.MarshalBinary()
+			got.scalarMultGLV(k, P)
+			want.scalarMult(b, P)
+			if !got.IsEqual(&want) {
+				test.ReportError(t, got, want, P, k)
+			}
+		}
+	})
+}
+
+func BenchmarkGLV(b *testing.B) {
+	k := randomScalar(b)
+	kb, _ := k.MarshalBinary()
+	P1, P2 := randomG1(b), randomG2(b)
+	b.Run("G1/Window", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			P1.scalarMult(kb, P1)
+		}
+	})
+	b.Run("G1/GLV", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			P1.scalarMultGLV(k, P1)
+		}
+	})
+	b.Run("G2/Window", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			P2.scalarMult(kb, P2)
+		}
+	})
+	b.Run("G2/GLS", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			P2.scalarMultGLV(k, P2)
+		}
+	})
+}
@@ -1,6 +1,9 @@
 package bls12381
 
-import "github.com/cloudflare/circl/ecc/bls12381/ff"
+import (
+	"github.com/cloudflare/circl/ecc/bls12381/ff"
+	"github.com/cloudflare/circl/internal/sha3"
+)
 
 // GtSize is the length in bytes of an element in Gt.
 const GtSize = ff.URootSize
@@ -20,3 +23,36 @@ func (z *Gt) Inv(x *Gt)                      { z.i.Inv(&x.i) }
 
 // Exp calculates z=x^n, where n is the exponent in big-endian order.
 func (z *Gt) Exp(x *Gt, n *Scalar) { b, _ := n.MarshalBinary(); z.i.Exp(&x.i, b) }
+
+// challengeSize is the number of bytes reduced into each challenge, so that
+// the bias of the reduction modulo ScalarOrder is less than 2^-257.
+const challengeSize = 64
+
+// ChallengesGT returns n scalars derived from the transcript, to be used as
+// independent challenges, e.g., as exponents of elements in Gt. The
+// domain-separation tag dst must be unique to the protocol using them. Each
+// scalar is obtained by reducing challengeSize consecutive bytes of the
+// output of SHAKE-256 on len(dst) || dst || transcript modulo ScalarOrder,
+// where len(dst) takes one byte. Hence, the first k of the n challenges
+// coincide with ChallengesGT(transcript, dst, k). It panics if n is negative
+// or if dst is longer than 255 bytes.
+func ChallengesGT(transcript, dst []byte, n int) []*Scalar {
+	if n < 0 {
+		panic("bls12381: negative number of challenges")
+	}
+	if len(dst) > 255 {
+		panic("bls12381: domain-separation tag too long")
+	}
+	h := sha3.NewShake256()
+	_, _ = h.Write([]byte{byte(len(dst))})
+	_, _ = h.Write(dst)
+	_, _ = h.Write(transcript)
+	var b [challengeSize]byte
+	c := make([]*Scalar, n)
+	for i := range c {
+		_, _ = h.Read(b[:])
+		c[i] = new(Scalar)
+		c[i].SetBytes(b[:])
+	}
+	return c
+}
@@ -1,10 +1,90 @@
 package bls12381
 
 import (
+	"bytes"
 	"crypto/rand"
+	"math/big"
 	"testing"
+
+	"github.com/cloudflare/circl/ecc/bls12381/ff"
+	"github.com/cloudflare/circl/internal/sha3"
+	"github.com/cloudflare/circl/internal/test"
 )
 
+func TestChallengesGT(t *testing.T) {
+	const n = 16
+	transcript := []byte("transcript")
+	dst := []byte("BLS12381-ChallengesGT-test")
+	c := ChallengesGT(transcript, dst, n)
+	if len(c) != n {
+		test.ReportError(t, len(c), n)
+	}
+
+	t.Run("deterministic", func(t *testing.T) {
+		d := ChallengesGT(transcript, dst, n)
+		for i := range c {
+			if c[i].IsEqual(d[i]) == 0 {
+				test.ReportError(t, d[i], c[i], i)
+			}
+		}
+		e := ChallengesGT(transcript, dst, n/2)
+		for i := range e {
+			if c[i].IsEqual(e[i]) == 0 {
+				test.ReportError(t, e[i], c[i], i)
+			}
+		}
+		f := ChallengesGT([]byte("Transcript"), dst, 1)
+		if c[0].IsEqual(f[0]) == 1 {
+			test.ReportError(t, f[0], c[0])
+		}
+		// Moving a byte from the tag to the transcript changes the
+		// challenges.
+		g := ChallengesGT(append([]byte("t"), transcript...), dst[:len(dst)-1], 1)
+		if c[0].IsEqual(g[0]) == 1 {
+			test.ReportError(t, g[0], c[0])
+		}
+	})
+
+	t.Run("distinct", func(t *testing.T) {
+		for i := range c {
+			for j := i + 1; j < n; j++ {
+				if c[i].IsEqual(c[j]) == 1 {
+					test.ReportError(t, c[i], c[j], i, j)
+				}
+			}
+		}
+	})
+
+	t.Run("reduced", func(t *testing.T) {
+		order := new(big.Int).SetBytes(ff.ScalarOrder())
+		h := sha3.NewShake256()
+		_, _ = h.Write([]byte{byte(len(dst))})
+		_, _ = h.Write(dst)
+		_, _ = h.Write(transcript)
+		var b [challengeSize]byte
+		for i := range c {
+			_, _ = h.Read(b[:])
+			want := new(big.Int).SetBytes(b[:])
+			want.Mod(want, order)
+			got, err := c[i].MarshalBinary()
+			test.CheckNoErr(t, err, "MarshalBinary failed")
+			if !bytes.Equal(got, want.FillBytes(make([]byte, ff.ScalarSize))) {
+				test.ReportError(t, got, want, i)
+			}
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		if got := ChallengesGT(transcript, dst, 0); len(got) != 0 {
+			test.ReportError(t, len(got), 0)
+		}
+		err := test.CheckPanic(func() { ChallengesGT(transcript, dst, -1) })
+		test.CheckNoErr(t, err, "ChallengesGT must panic for negative n")
+		err = test.CheckPanic(func() { ChallengesGT(transcript, make([]byte, 256), 1) })
+		test.CheckNoErr(t, err, "ChallengesGT must panic for long tags")
+	})
+}
+
 func BenchmarkGt(b *testing.B) {
 	sc := &Scalar{}
 	err := sc.Random(rand.Reader)
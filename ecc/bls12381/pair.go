@@ -1,6 +1,12 @@
 package bls12381
 
-import "github.com/cloudflare/circl/ecc/bls12381/ff"
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"github.com/cloudflare/circl/ecc/bls12381/ff"
+)
 
 // Pair calculates the ate-pairing of P and Q.
 func Pair(P *G1, Q *G2) *Gt {
@@ -96,6 +102,57 @@ func ProdPair(P []*G1, Q []*G2, n []*Scalar) *Gt {
 	return e
 }
 
+// ProdPairParallel calculates the product of pairings \Prod_i pair(Pi,Qi).
+// The pairs are split among workers goroutines, each one accumulating the
+// Miller loops of its share, and a single final exponentiation is applied to
+// the product of the partial results, so the output does not depend on the
+// number of workers. If workers is not positive, runtime.GOMAXPROCS(0) is
+// used. It returns ctx.Err() if ctx is done before the Miller loops finish.
+func ProdPairParallel(ctx context.Context, workers int, P []*G1, Q []*G2) (*Gt, error) {
+	if len(P) != len(Q) {
+		panic("mismatch length of inputs")
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(P) {
+		workers = len(P)
+	}
+
+	affineP := affinize(P)
+	partial := make([]ff.Fp12, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			mi := new(ff.Fp12)
+			acc := &partial[w]
+			acc.SetOne()
+			for i := w * len(P) / workers; i < (w+1)*len(P)/workers; i++ {
+				if ctx.Err() != nil {
+					return
+				}
+				miller(mi, &affineP[i], Q[i])
+				acc.Mul(acc, mi)
+			}
+		}(w)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	out := new(ff.Fp12)
+	out.SetOne()
+	for i := range partial {
+		out.Mul(out, &partial[i])
+	}
+	e := &Gt{}
+	finalExp(e, out)
+	return e, nil
+}
+
 // ProdPairFrac computes the product e(P, Q)^sign where sign is 1 or -1
 func ProdPairFrac(P []*G1, Q []*G2, signs []int) *Gt {
 	if len(P) != len(Q) || len(P) != len(signs) {
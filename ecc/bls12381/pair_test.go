@@ -1,6 +1,7 @@
 package bls12381
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"testing"
@@ -79,6 +80,62 @@ func TestProdPairFrac(t *testing.T) {
 	}
 }
 
+func TestProdPairParallel(t *testing.T) {
+	const N = 13
+	listG1 := [N]*G1{}
+	listG2 := [N]*G2{}
+	listSigns := [N]int{}
+	for j := 0; j < N; j++ {
+		listG1[j] = randomG1(t)
+		listG2[j] = randomG2(t)
+		listSigns[j] = 1
+	}
+
+	for _, n := range []int{0, 1, N} {
+		want := ProdPairFrac(listG1[:n], listG2[:n], listSigns[:n])
+		for _, workers := range []int{0, 1, 2, 5, N, 2 * N} {
+			got, err := ProdPairParallel(context.Background(), workers, listG1[:n], listG2[:n])
+			test.CheckNoErr(t, err, "ProdPairParallel failed")
+			if !got.IsEqual(want) {
+				test.ReportError(t, got, want, n, workers)
+			}
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := ProdPairParallel(ctx, 2, listG1[:], listG2[:])
+	if err != context.Canceled {
+		test.ReportError(t, err, context.Canceled)
+	}
+}
+
+func TestPairAllocs(t *testing.T) {
+	// The temporaries of the Miller loop, the final exponentiation and the
+	// arithmetic of Fp12 live on the stack, so a pairing only allocates
+	// its output.
+	P := randomG1(t)
+	Q := randomG2(t)
+	P.toAffine()
+	mi := new(ff.Fp12)
+	g := new(Gt)
+	var z ff.Fp12
+	for _, c := range []struct {
+		name   string
+		allocs float64
+		f      func()
+	}{
+		{"Miller", 0, func() { miller(mi, P, Q) }},
+		{"FinalExp", 0, func() { finalExp(g, mi) }},
+		{"Fp12", 0, func() { z.Mul(mi, mi); z.Sqr(&z); z.Inv(&z) }},
+		{"Pair", 1, func() { Pair(P, Q) }},
+	} {
+		if got := testing.AllocsPerRun(4, c.f); got > c.allocs {
+			test.ReportError(t, got, c.allocs, c.name)
+		}
+	}
+}
+
 func TestInputs(t *testing.T) {
 	t.Run("Pair", func(t *testing.T) {
 		P := *randomG1(t)
@@ -233,3 +290,28 @@ func BenchmarkPair(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkProdPairParallel(b *testing.B) {
+	const N = 128
+	listG1 := [N]*G1{}
+	listG2 := [N]*G2{}
+	listSigns := [N]int{}
+	for i := 0; i < N; i++ {
+		listG1[i] = randomG1(b)
+		listG2[i] = randomG2(b)
+		listSigns[i] = 1
+	}
+
+	b.Run("ProdPairFrac", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ProdPairFrac(listG1[:], listG2[:], listSigns[:])
+		}
+	})
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%v", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = ProdPairParallel(context.Background(), workers, listG1[:], listG2[:])
+			}
+		})
+	}
+}
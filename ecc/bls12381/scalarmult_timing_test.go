@@ -0,0 +1,57 @@
+//go:build timing
+// +build timing
+
+package bls12381
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+// TestScalarMultTiming compares the running time of the scalar
+// multiplications on random scalars and on scalars with mostly zero
+// digits. It is sensitive to noise, so it only runs with the timing build
+// tag: go test -tags timing -run Timing.
+func TestScalarMultTiming(t *testing.T) {
+	P1, P2 := randomG1(t), randomG2(t)
+	var Q1 G1
+	var Q2 G2
+	for _, f := range []struct {
+		name string
+		mul  func(k *Scalar)
+	}{
+		{"G1/ScalarMult", func(k *Scalar) { Q1.ScalarMult(k, P1) }},
+		{"G1/ScalarBaseMult", func(k *Scalar) { Q1.ScalarBaseMult(k) }},
+		{"G2/ScalarMult", func(k *Scalar) { Q2.ScalarMult(k, P2) }},
+		{"G2/ScalarBaseMult", func(k *Scalar) { Q2.ScalarBaseMult(k) }},
+	} {
+		t.Run(f.name, func(t *testing.T) { testScalarMultTiming(t, f.mul) })
+	}
+}
+
+func testScalarMultTiming(t *testing.T, mul func(k *Scalar)) {
+	const testTimes = 1 << 9
+	const maxRelDiff = 0.05
+
+	var small Scalar
+	small.SetUint64(1)
+	var times [2][]time.Duration
+	for i := 0; i < testTimes; i++ {
+		// Alternate the classes to spread the noise evenly.
+		for c, k := range []*Scalar{randomScalar(t), &small} {
+			start := time.Now()
+			mul(k)
+			times[c] = append(times[c], time.Since(start))
+		}
+	}
+
+	median := func(d []time.Duration) float64 {
+		sort.Slice(d, func(i, j int) bool { return d[i] < d[j] })
+		return float64(d[len(d)/2])
+	}
+	random, sparse := median(times[0]), median(times[1])
+	if diff := (random - sparse) / random; diff > maxRelDiff || diff < -maxRelDiff {
+		t.Errorf("median times differ: random %v ns, small %v ns", random, sparse)
+	}
+}
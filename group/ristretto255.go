@@ -1,6 +1,7 @@
 package group
 
 import (
+	"bytes"
 	"crypto"
 	_ "crypto/sha512"
 	"fmt"
@@ -94,12 +95,18 @@ func (g ristrettoGroup) HashToElement(msg, dst []byte) Element {
 	// Appendix B - Hashing to ristretto255
 	// https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-hash-to-curve-14#appendix-B
 	// SuiteID: ristretto255_XMD:SHA-512_R255MAP_RO_
-	var buf [32]byte
 	xmd := expander.NewExpanderMD(crypto.SHA512, dst)
-	uniformBytes := xmd.Expand(msg, 64)
-	copy(buf[:], uniformBytes[:32])
+	return ristrettoFromUniformBytes(xmd.Expand(msg, 64))
+}
+
+// ristrettoFromUniformBytes is the one-way map of Section 4.3.4 of
+// draft-irtf-cfrg-ristretto255-decaf448, which maps 64 uniformly random
+// bytes to an element, as the sum of the Elligator images of each half.
+func ristrettoFromUniformBytes(b []byte) *ristrettoElement {
+	var buf [32]byte
+	copy(buf[:], b[:32])
 	p0 := new(r255.Point).SetElligator(&buf)
-	copy(buf[:], uniformBytes[32:])
+	copy(buf[:], b[32:64])
 	p1 := new(r255.Point).SetElligator(&buf)
 	p0.Add(p0, p1)
 
@@ -190,8 +197,20 @@ func (e *ristrettoElement) MarshalBinary() ([]byte, error) {
 	return e.p.MarshalBinary()
 }
 
+// UnmarshalBinary decodes an element, rejecting the encodings that are not
+// canonical, i.e., those that differ from the encoding of the element they
+// decode to. The underlying decoder does not check it for field elements
+// that are not reduced, nor for the most significant bit.
 func (e *ristrettoElement) UnmarshalBinary(data []byte) error {
-	return e.p.UnmarshalBinary(data)
+	var p r255.Point
+	if err := p.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	if !bytes.Equal(p.Bytes(), data) {
+		return ErrUnmarshal
+	}
+	e.p = p
+	return nil
 }
 
 func (s *ristrettoScalar) Group() Group                { return Ristretto255 }
@@ -2,6 +2,7 @@
 package fp25519
 
 import (
+	"crypto/subtle"
 	"errors"
 
 	"github.com/cloudflare/circl/internal/conv"
@@ -45,17 +46,57 @@ func SetOne(x *Elt) { *x = Elt{}; x[0] = 1 }
 // Neg calculates z = -x.
 func Neg(z, x *Elt) { Sub(z, &p, x) }
 
+// sqrtMinusOne is a square root of -1.
+var sqrtMinusOne = Elt{
+	0xb0, 0xa0, 0x0e, 0x4a, 0x27, 0x1b, 0xee, 0xc4,
+	0x78, 0xe4, 0x2f, 0xad, 0x06, 0x18, 0x43, 0x2f,
+	0xa7, 0xd7, 0xfb, 0x3d, 0x99, 0x00, 0x4d, 0x2b,
+	0x0b, 0xdf, 0xc1, 0x4f, 0x80, 0x24, 0x83, 0x2b,
+}
+
 // InvSqrt calculates z = sqrt(x/y) iff x/y is a quadratic-residue, which is
 // indicated by returning isQR = true. Otherwise, when x/y is a quadratic
 // non-residue, z will have an undetermined value and isQR = false.
 func InvSqrt(z, x, y *Elt) (isQR bool) {
-	sqrtMinusOne := &Elt{
-		0xb0, 0xa0, 0x0e, 0x4a, 0x27, 0x1b, 0xee, 0xc4,
-		0x78, 0xe4, 0x2f, 0xad, 0x06, 0x18, 0x43, 0x2f,
-		0xa7, 0xd7, 0xfb, 0x3d, 0x99, 0x00, 0x4d, 0x2b,
-		0x0b, 0xdf, 0xc1, 0x4f, 0x80, 0x24, 0x83, 0x2b,
+	t0, t1, t2 := &Elt{}, &Elt{}, &Elt{}
+	invSqrtCandidate(z, t0, x, y)
+	// Checking whether y z^2 == x
+	Sub(t1, t0, x) // t1 = t0-u
+	Add(t2, t0, x) // t2 = t0+u
+	if IsZero(t1) {
+		return true
+	} else if IsZero(t2) {
+		Mul(z, z, &sqrtMinusOne) // z = z*sqrt(-1)
+		return true
+	} else {
+		return false
 	}
-	t0, t1, t2, t3 := &Elt{}, &Elt{}, &Elt{}, &Elt{}
+}
+
+// InvSqrtCT is as InvSqrt, but it runs in constant time, and it returns
+// isQR = 1 if x/y is a quadratic-residue, and isQR = 0 otherwise.
+func InvSqrtCT(z, x, y *Elt) (isQR int) {
+	t0, t1, t2 := &Elt{}, &Elt{}, &Elt{}
+	invSqrtCandidate(z, t0, x, y)
+	Sub(t1, t0, x) // t1 = t0-u
+	Add(t2, t0, x) // t2 = t0+u
+	isRoot, isNegRoot := isZeroCT(t1), isZeroCT(t2)
+	Mul(t0, z, &sqrtMinusOne)
+	Cmov(z, t0, uint((1-isRoot)&isNegRoot))
+	return isRoot | isNegRoot
+}
+
+// isZeroCT returns 1 if x is equal to 0, and 0 otherwise, in constant time.
+func isZeroCT(x *Elt) int {
+	Modp(x)
+	return subtle.ConstantTimeCompare(x[:], (&Elt{})[:])
+}
+
+// invSqrtCandidate calculates z = xy^3(xy^7)^((p-5)/8) and yz2 = y*z^2,
+// such that z is sqrt(x/y) if yz2 = x, or sqrt(-x/y)/sqrt(-1) if yz2 = -x.
+func invSqrtCandidate(z, yz2, x, y *Elt) {
+	t0 := yz2
+	t1, t2, t3 := &Elt{}, &Elt{}, &Elt{}
 
 	Mul(t0, x, y)   // t0 = u*v
 	Sqr(t1, y)      // t1 = v^2
@@ -116,19 +157,8 @@ func InvSqrt(z, x, y *Elt) (isQR bool) {
 	Mul(Tab[2], Tab[2], Tab[3])
 
 	Mul(z, t3, t2) // z = xy^(p+3)/8 = xy^3*(xy^7)^(p-5)/8
-	// Checking whether y z^2 == x
 	Sqr(t0, z)     // t0 = z^2
 	Mul(t0, t0, y) // t0 = yz^2
-	Sub(t1, t0, x) // t1 = t0-u
-	Add(t2, t0, x) // t2 = t0+u
-	if IsZero(t1) {
-		return true
-	} else if IsZero(t2) {
-		Mul(z, z, sqrtMinusOne) // z = z*sqrt(-1)
-		return true
-	} else {
-		return false
-	}
 }
 
 // Inv calculates z = 1/x mod p.
@@ -427,3 +427,29 @@ func BenchmarkFp(b *testing.B) {
 		}
 	})
 }
+
+func TestInvSqrtCT(t *testing.T) {
+	const numTests = 1 << 9
+	var x, y, got, want Elt
+	for i := 0; i < numTests; i++ {
+		_, _ = rand.Read(x[:])
+		_, _ = rand.Read(y[:])
+
+		isQR := InvSqrt(&want, &x, &y)
+		wantQR := 0
+		if isQR {
+			wantQR = 1
+		}
+		gotQR := InvSqrtCT(&got, &x, &y)
+		if gotQR != wantQR {
+			test.ReportError(t, gotQR, wantQR, x, y)
+		}
+		if isQR {
+			Modp(&got)
+			Modp(&want)
+			if got != want {
+				test.ReportError(t, got, want, x, y)
+			}
+		}
+	}
+}
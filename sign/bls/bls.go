@@ -12,7 +12,9 @@
 // function e(G1,G2) -> Gt.
 // Thus, choosing keys in G1 implies that signature values are internally
 // represented in G2; or viceversa. Use the types KeyG1SigG2 or KeyG2SigG1
-// to express this preference.
+// to express this preference. KeyG1SigG2 is the minimal-pubkey-size variant
+// of the draft, whose ciphersuite for the BASIC mode is
+// BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_.
 //
 // # Serialization
 //
@@ -91,10 +93,10 @@ func (k *PrivateKey[K]) PublicKey() *PublicKey[K] {
 		switch any(k).(type) {
 		case *PrivateKey[G1]:
 			kk := any(&k.pub.key).(*G1)
-			kk.g.ScalarMult(&k.key, GG.G1Generator())
+			kk.g.ScalarBaseMult(&k.key)
 		case *PrivateKey[G2]:
 			kk := any(&k.pub.key).(*G2)
-			kk.g.ScalarMult(&k.key, GG.G2Generator())
+			kk.g.ScalarBaseMult(&k.key)
 		default:
 			panic(ErrInvalid)
 		}
@@ -360,11 +362,25 @@ func Aggregate[K KeyGroup](k K, sigs []Signature) (Signature, error) {
 // VerifyAggregate returns true if the aggregated signature is valid for
 // the list of messages and public keys provided. The slices must have
 // equal size and have at least one element.
+//
+// As required by the BASIC mode, the messages must be pairwise distinct;
+// otherwise, it returns false. This prevents rogue-key attacks, in which a
+// public key chosen as a function of other public keys is used to forge an
+// aggregate signature on a message shared by them. Public keys and
+// aggregate signatures at the point at infinity are rejected too.
 func VerifyAggregate[K KeyGroup](pubs []*PublicKey[K], msgs [][]byte, aggSig Signature) bool {
 	if len(pubs) != len(msgs) || len(pubs) == 0 {
 		return false
 	}
 
+	seen := make(map[string]struct{}, len(msgs))
+	for _, m := range msgs {
+		if _, ok := seen[string(m)]; ok {
+			return false
+		}
+		seen[string(m)] = struct{}{}
+	}
+
 	for _, p := range pubs {
 		if !p.Validate() {
 			return false
@@ -392,7 +408,7 @@ func VerifyAggregate[K KeyGroup](pubs []*PublicKey[K], msgs [][]byte, aggSig Sig
 		}
 
 		err := listG2[n].SetBytes(aggSig)
-		if err != nil {
+		if err != nil || listG2[n].IsIdentity() {
 			return false
 		}
 
@@ -407,7 +423,7 @@ func VerifyAggregate[K KeyGroup](pubs []*PublicKey[K], msgs [][]byte, aggSig Sig
 		}
 
 		err := listG1[n].SetBytes(aggSig)
-		if err != nil {
+		if err != nil || listG1[n].IsIdentity() {
 			return false
 		}
 
@@ -124,6 +124,11 @@ func testErrors[K bls.KeyGroup](t *testing.T) {
 
 	// VerifyAggregate empty signature
 	test.CheckOk(bls.VerifyAggregate([]*bls.PublicKey[K]{pub}, [][]byte{msg}, nil) == false, "should fail: empty signature", t)
+
+	// VerifyAggregate signature at infinity
+	infinity := make(bls.Signature, len(sig))
+	infinity[0] = 0xC0
+	test.CheckOk(bls.VerifyAggregate([]*bls.PublicKey[K]{pub}, [][]byte{msg}, infinity) == false, "should fail: signature at infinity", t)
 }
 
 func testAggregation[K bls.KeyGroup](t *testing.T) {
@@ -135,10 +140,12 @@ func testAggregation[K bls.KeyGroup](t *testing.T) {
 	msgs := make([][]byte, N)
 	sigs := make([]bls.Signature, N)
 	pubKeys := make([]*bls.PublicKey[K], N)
+	privs := make([]*bls.PrivateKey[K], N)
 
 	for i := range sigs {
 		priv, err := bls.KeyGen[K](ikm[:], nil, nil)
 		test.CheckNoErr(t, err, "failed to keygen")
+		privs[i] = priv
 		pubKeys[i] = priv.PublicKey()
 
 		msgs[i] = []byte(fmt.Sprintf("Message number: %v", i))
@@ -150,6 +157,14 @@ func testAggregation[K bls.KeyGroup](t *testing.T) {
 
 	ok := bls.VerifyAggregate(pubKeys, msgs, aggSig)
 	test.CheckOk(ok, "failed to verify aggregated signature", t)
+
+	// Repeated messages are rejected, even for a valid aggregate.
+	msgs[1] = msgs[0]
+	sigs[1] = bls.Sign(privs[1], msgs[1])
+	aggSig, err = bls.Aggregate(*new(K), sigs)
+	test.CheckNoErr(t, err, "failed to aggregate")
+	ok = bls.VerifyAggregate(pubKeys, msgs, aggSig)
+	test.CheckOk(!ok, "should fail: repeated messages", t)
 }
 
 func BenchmarkBls(b *testing.B) {
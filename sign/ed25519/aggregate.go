@@ -0,0 +1,160 @@
+package ed25519
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+)
+
+// aggDomain separates the hash that derives the aggregation coefficients.
+const aggDomain = "circl/ed25519/aggregate-same-message"
+
+// AggVerifier verifies Ed25519 signatures of many signers on a common
+// message, which are half-aggregated into the signature
+//
+//	R_1 || ... || R_n || s,
+//
+// where s = sum z_i*S_i for coefficients z_i derived from the message, the
+// public keys, and every R_i. Hence, storage is n*32+32 bytes, rather than
+// n*64 bytes, but the size still grows linearly with the number of signers.
+//
+// Verification computes one SHA-512 per signer, and checks
+//
+//	[8][s]B = [8](sum [z_i]R_i + [z_i*k_i]A_i)
+//
+// with a single multi-scalar multiplication, where k_i = H(R_i||A_i||M).
+// Since the equation is cofactored, it can accept signatures rejected by
+// Verify, but only if their points have components of small order, which
+// honest signers do not produce.
+//
+// Reference: Chalkias et al., "Non-interactive half-aggregation of EdDSA and
+// variants of Schnorr signatures", https://eprint.iacr.org/2021/350.
+type AggVerifier struct {
+	encR   [][paramB]byte
+	points []pointR1      // -R_i followed by -A_i.
+	coeffs [][paramB]byte // z_i followed by z_i*k_i.
+	s      [paramB]byte
+}
+
+// AggregateSameMessage returns an AggVerifier for the signatures of the
+// message under the corresponding public keys. It returns false if there
+// are no public keys, if the number of public keys and signatures differ,
+// or if any of them is not a valid encoding. The signatures themselves are
+// not verified.
+func AggregateSameMessage(message []byte, publics []PublicKey, sigs [][]byte) (*AggVerifier, bool) {
+	if len(publics) != len(sigs) {
+		return nil, false
+	}
+	rs := make([][]byte, len(sigs))
+	for i, sig := range sigs {
+		if len(sig) != SignatureSize || !isLessThanOrder(sig[paramB:]) {
+			return nil, false
+		}
+		rs[i] = sig[:paramB]
+	}
+	v, ok := newAggVerifier(message, publics, rs)
+	if !ok {
+		return nil, false
+	}
+	for i, sig := range sigs {
+		calculateS(v.s[:], v.s[:], v.coeffs[i][:], sig[paramB:])
+	}
+	return v, true
+}
+
+// NewAggVerifier returns an AggVerifier for the aggregate signature, as
+// returned by AggVerifier.Signature, of the message under the public keys.
+// It returns false if there are no public keys, or if the aggregate
+// signature or any public key is not a valid encoding.
+func NewAggVerifier(message []byte, publics []PublicKey, aggregate []byte) (*AggVerifier, bool) {
+	n := len(publics)
+	if len(aggregate) != (n+1)*paramB || !isLessThanOrder(aggregate[n*paramB:]) {
+		return nil, false
+	}
+	rs := make([][]byte, n)
+	for i := range rs {
+		rs[i] = aggregate[i*paramB : (i+1)*paramB]
+	}
+	v, ok := newAggVerifier(message, publics, rs)
+	if !ok {
+		return nil, false
+	}
+	copy(v.s[:], aggregate[n*paramB:])
+	return v, true
+}
+
+// newAggVerifier decodes the points and computes the coefficients of the
+// verification equation, leaving the aggregated s unset. It rejects an
+// empty set of signers, for which the equation holds for any message.
+func newAggVerifier(message []byte, publics []PublicKey, rs [][]byte) (*AggVerifier, bool) {
+	n := len(publics)
+	if n == 0 {
+		return nil, false
+	}
+	v := &AggVerifier{
+		encR:   make([][paramB]byte, n),
+		points: make([]pointR1, 2*n),
+		coeffs: make([][paramB]byte, 2*n),
+	}
+
+	// The coefficients z_i are 128-bit values derived from a hash of all
+	// the inputs but the S_i, following Section 4 of the reference.
+	H := sha512.New()
+	_, _ = H.Write([]byte(aggDomain))
+	for i := range rs {
+		if len(publics[i]) != PublicKeySize ||
+			!v.points[i].FromBytes(rs[i]) ||
+			!v.points[n+i].FromBytes(publics[i]) {
+			return nil, false
+		}
+		v.points[i].neg()
+		v.points[n+i].neg()
+		copy(v.encR[i][:], rs[i])
+		_, _ = H.Write(rs[i])
+		_, _ = H.Write(publics[i])
+	}
+	var l [8]byte
+	binary.LittleEndian.PutUint64(l[:], uint64(len(message)))
+	_, _ = H.Write(l[:])
+	_, _ = H.Write(message)
+	seed := H.Sum(nil)
+
+	var zero [paramB]byte
+	for i := range rs {
+		H.Reset()
+		_, _ = H.Write(seed)
+		binary.LittleEndian.PutUint64(l[:], uint64(i))
+		_, _ = H.Write(l[:])
+		copy(v.coeffs[i][:16], H.Sum(nil))
+
+		H.Reset()
+		_, _ = H.Write(rs[i])
+		_, _ = H.Write(publics[i])
+		_, _ = H.Write(message)
+		k := H.Sum(nil)
+		reduceModOrder(k, true)
+		calculateS(v.coeffs[n+i][:], zero[:], v.coeffs[i][:], k[:paramB])
+	}
+	return v, true
+}
+
+// Signature returns the aggregate signature R_1 || ... || R_n || s.
+func (v *AggVerifier) Signature() []byte {
+	out := make([]byte, 0, (len(v.encR)+1)*paramB)
+	for i := range v.encR {
+		out = append(out, v.encR[i][:]...)
+	}
+	return append(out, v.s[:]...)
+}
+
+// Verify returns true if the aggregate signature is valid.
+func (v *AggVerifier) Verify() bool {
+	var P, id pointR1
+	points := make([]pointR1, len(v.points))
+	copy(points, v.points)
+	P.multiMult(v.s[:], points, v.coeffs)
+	P.double()
+	P.double()
+	P.double()
+	id.SetIdentity()
+	return P.isEqual(&id)
+}
@@ -0,0 +1,102 @@
+package ed25519_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed25519"
+)
+
+func aggTestData(t testing.TB, n int) (msg []byte, pubs []ed25519.PublicKey, sigs [][]byte) {
+	msg = []byte("common message")
+	pubs = make([]ed25519.PublicKey, n)
+	sigs = make([][]byte, n)
+	for i := range pubs {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		test.CheckNoErr(t, err, "GenerateKey failed")
+		pubs[i] = pub
+		sigs[i] = ed25519.Sign(priv, msg)
+	}
+	return msg, pubs, sigs
+}
+
+func TestAggregateSameMessage(t *testing.T) {
+	for _, n := range []int{1, 2, 17} {
+		msg, pubs, sigs := aggTestData(t, n)
+
+		v, ok := ed25519.AggregateSameMessage(msg, pubs, sigs)
+		test.CheckOk(ok, "AggregateSameMessage failed", t)
+		test.CheckOk(v.Verify(), "valid aggregate rejected", t)
+
+		agg := v.Signature()
+		test.CheckOk(len(agg) == (n+1)*32, "bad aggregate size", t)
+		v, ok = ed25519.NewAggVerifier(msg, pubs, agg)
+		test.CheckOk(ok && v.Verify(), "valid decoded aggregate rejected", t)
+
+		// Each tampering is detected by individual verification, so it
+		// must be detected by the aggregate one.
+		for i := range sigs {
+			bad := make([][]byte, n)
+			copy(bad, sigs)
+			bad[i] = bytes.Clone(sigs[i])
+			bad[i][40] ^= 1
+			test.CheckOk(!ed25519.Verify(pubs[i], msg, bad[i]), "bad signature accepted", t)
+			v, ok := ed25519.AggregateSameMessage(msg, pubs, bad)
+			if ok && v.Verify() {
+				test.ReportError(t, true, false, n, i)
+			}
+		}
+		if n > 1 {
+			swapped := make([]ed25519.PublicKey, n)
+			copy(swapped, pubs)
+			swapped[0], swapped[1] = swapped[1], swapped[0]
+			v, ok := ed25519.AggregateSameMessage(msg, swapped, sigs)
+			test.CheckOk(ok && !v.Verify(), "swapped keys accepted", t)
+		}
+		v, ok = ed25519.AggregateSameMessage([]byte("other message"), pubs, sigs)
+		test.CheckOk(ok && !v.Verify(), "wrong message accepted", t)
+
+		badAgg := bytes.Clone(agg)
+		badAgg[len(badAgg)-1] ^= 1
+		if v, ok := ed25519.NewAggVerifier(msg, pubs, badAgg); ok && v.Verify() {
+			t.Fatal("bad aggregate accepted")
+		}
+	}
+
+	msg, pubs, sigs := aggTestData(t, 2)
+	_, ok := ed25519.AggregateSameMessage(msg, pubs[:1], sigs)
+	test.CheckOk(!ok, "length mismatch accepted", t)
+	_, ok = ed25519.NewAggVerifier(msg, pubs, make([]byte, 32))
+	test.CheckOk(!ok, "short aggregate accepted", t)
+	_, ok = ed25519.AggregateSameMessage(msg, nil, nil)
+	test.CheckOk(!ok, "empty signer set accepted", t)
+	_, ok = ed25519.NewAggVerifier(msg, nil, make([]byte, 32))
+	test.CheckOk(!ok, "empty aggregate accepted", t)
+}
+
+func BenchmarkAggregateSameMessage(b *testing.B) {
+	for _, n := range []int{16, 64} {
+		msg, pubs, sigs := aggTestData(b, n)
+		v, _ := ed25519.AggregateSameMessage(msg, pubs, sigs)
+		b.Run(fmt.Sprintf("Verify/%v", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for j := range sigs {
+					ed25519.Verify(pubs[j], msg, sigs[j])
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Aggregate/%v", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = ed25519.AggregateSameMessage(msg, pubs, sigs)
+			}
+		})
+		b.Run(fmt.Sprintf("AggVerify/%v", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				v.Verify()
+			}
+		})
+	}
+}
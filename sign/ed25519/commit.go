@@ -0,0 +1,98 @@
+package ed25519
+
+import (
+	"bytes"
+	cryptoRand "crypto/rand"
+	"crypto/sha512"
+	"io"
+	"strconv"
+)
+
+const (
+	// CommitmentSize is the size in bytes of key commitments.
+	CommitmentSize = sha512.Size
+	// commitSaltSize is the size in bytes of the salt of key commitments.
+	commitSaltSize = 32
+	// CommittedSignatureSize is the size in bytes of the signatures
+	// returned by SignCommitted.
+	CommittedSignatureSize = SignatureSize + commitSaltSize
+)
+
+// commitDomain separates key commitments from other uses of SHA-512.
+const commitDomain = "circl/ed25519 key commitment"
+
+// NewKeyCommitment returns a commitment to the public key and the salt
+// that opens it. The construction is as follows:
+//
+//	salt       = 32 random bytes read from rand
+//	commitment = SHA-512(commitDomain || A || salt)
+//
+// where A is the public key. The commitment can be published ahead of time;
+// the salt hides the public key until a signature made by SignCommitted
+// with the same salt is released. If rand is nil, crypto/rand.Reader is
+// used. It will panic if len(public) is not PublicKeySize.
+func NewKeyCommitment(public PublicKey, rand io.Reader) (commitment, salt []byte, err error) {
+	if l := len(public); l != PublicKeySize {
+		panic("ed25519: bad public key length: " + strconv.Itoa(l))
+	}
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	salt = make([]byte, commitSaltSize)
+	if _, err := io.ReadFull(rand, salt); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, nil, err
+	}
+	return keyCommitment(public, salt), salt, nil
+}
+
+// SignCommitted signs the message with privateKey, binding the signature to
+// the commitment to the public key opened by salt, as returned by
+// NewKeyCommitment:
+//
+//	sig = Ed25519ctx(privateKey, message, ctx = commitment) || salt
+//
+// Signatures have CommittedSignatureSize bytes. It will panic if
+// len(privateKey) is not PrivateKeySize, or if salt was not returned by
+// NewKeyCommitment.
+func SignCommitted(privateKey PrivateKey, message, salt []byte) []byte {
+	if l := len(privateKey); l != PrivateKeySize {
+		panic("ed25519: bad private key length: " + strconv.Itoa(l))
+	}
+	if l := len(salt); l != commitSaltSize {
+		panic("ed25519: bad commitment salt length: " + strconv.Itoa(l))
+	}
+	commitment := keyCommitment(privateKey[SeedSize:], salt)
+
+	sig := make([]byte, CommittedSignatureSize)
+	signAll(sig[:SignatureSize], privateKey, message, commitment, false)
+	copy(sig[SignatureSize:], salt)
+	return sig
+}
+
+// VerifyCommitted returns true if sig is a valid signature of the message
+// produced by SignCommitted under the public key, and commitment is the
+// commitment to the public key opened by the salt of sig.
+func VerifyCommitted(public PublicKey, message, sig, commitment []byte) bool {
+	if len(public) != PublicKeySize ||
+		len(sig) != CommittedSignatureSize ||
+		len(commitment) != CommitmentSize {
+		return false
+	}
+	salt := sig[SignatureSize:]
+	if !bytes.Equal(keyCommitment(public, salt), commitment) {
+		return false
+	}
+	return verify(public, message, sig[:SignatureSize], commitment, false)
+}
+
+// keyCommitment returns SHA-512(commitDomain || public || salt).
+func keyCommitment(public, salt []byte) []byte {
+	H := sha512.New()
+	_, _ = H.Write([]byte(commitDomain))
+	_, _ = H.Write(public)
+	_, _ = H.Write(salt)
+	return H.Sum(nil)
+}
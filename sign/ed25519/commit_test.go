@@ -0,0 +1,51 @@
+package ed25519_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed25519"
+)
+
+func TestSignCommitted(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	test.CheckNoErr(t, err, "failed to generate key")
+	otherPub, _, _ := ed25519.GenerateKey(rand.Reader)
+	msg := []byte("message")
+
+	commitment, salt, err := ed25519.NewKeyCommitment(pub, rand.Reader)
+	test.CheckNoErr(t, err, "NewKeyCommitment failed")
+	sig := ed25519.SignCommitted(priv, msg, salt)
+	if len(sig) != ed25519.CommittedSignatureSize || len(commitment) != ed25519.CommitmentSize {
+		test.ReportError(t, len(sig), ed25519.CommittedSignatureSize, len(commitment))
+	}
+	test.CheckOk(ed25519.VerifyCommitted(pub, msg, sig, commitment), "VerifyCommitted failed", t)
+
+	// Commitments are salted.
+	commitment2, salt2, err := ed25519.NewKeyCommitment(pub, nil)
+	test.CheckNoErr(t, err, "NewKeyCommitment failed")
+	sig2 := ed25519.SignCommitted(priv, msg, salt2)
+	test.CheckOk(string(commitment) != string(commitment2), "commitments must differ", t)
+	test.CheckOk(!ed25519.VerifyCommitted(pub, msg, sig2, commitment), "mixed commitment accepted", t)
+	test.CheckOk(!ed25519.VerifyCommitted(pub, msg, sig, commitment2), "mixed commitment accepted", t)
+
+	// The plain signature is bound to the commitment through the context.
+	test.CheckOk(!ed25519.Verify(pub, msg, sig[:ed25519.SignatureSize]), "plain Verify accepted", t)
+
+	test.CheckOk(!ed25519.VerifyCommitted(otherPub, msg, sig, commitment), "wrong key accepted", t)
+	test.CheckOk(!ed25519.VerifyCommitted(pub, []byte("other"), sig, commitment), "wrong message accepted", t)
+	for _, pos := range []int{0, ed25519.SignatureSize - 1, ed25519.SignatureSize, ed25519.CommittedSignatureSize - 1} {
+		bad := append([]byte{}, sig...)
+		bad[pos] ^= 1
+		test.CheckOk(!ed25519.VerifyCommitted(pub, msg, bad, commitment), "tampered signature accepted", t)
+	}
+	bad := append([]byte{}, commitment...)
+	bad[0] ^= 1
+	test.CheckOk(!ed25519.VerifyCommitted(pub, msg, sig, bad), "tampered commitment accepted", t)
+	test.CheckOk(!ed25519.VerifyCommitted(pub, msg, sig[:ed25519.SignatureSize], commitment), "short signature accepted", t)
+
+	_, _, err = ed25519.NewKeyCommitment(pub, bytes.NewReader(make([]byte, 31)))
+	test.CheckIsErr(t, err, "short read of the salt accepted")
+}
@@ -0,0 +1,41 @@
+package ed25519
+
+import (
+	"crypto/sha512"
+	"encoding"
+	"fmt"
+)
+
+// CtxVerifier verifies Ed25519ctx signatures that share one context string.
+// The SHA-512 state after absorbing the dom2 prefix is computed once, and it
+// is restored on each verification instead of hashing the prefix again.
+// A CtxVerifier is safe for concurrent use by multiple goroutines.
+type CtxVerifier struct {
+	state []byte
+}
+
+// NewCtxVerifier returns a CtxVerifier for the given context, which must be
+// non-empty and not longer than ContextMaxSize.
+func NewCtxVerifier(context string) (*CtxVerifier, error) {
+	if len(context) == 0 || len(context) > ContextMaxSize {
+		return nil, fmt.Errorf("ed25519: bad context length: %v", len(context))
+	}
+	h := sha512.New()
+	writeDom(h, []byte(context), false)
+	state, err := h.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &CtxVerifier{state: state}, nil
+}
+
+// Verify returns true if the signature is a valid Ed25519ctx signature of
+// the message under the context of c. It is equivalent to VerifyWithCtx.
+func (c *CtxVerifier) Verify(public PublicKey, message, signature []byte) bool {
+	var v VerifyContext
+	h := sha512.New()
+	if err := h.(encoding.BinaryUnmarshaler).UnmarshalBinary(c.state); err != nil {
+		return false
+	}
+	return v.verifyDom(public, message, signature, h)
+}
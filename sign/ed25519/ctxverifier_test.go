@@ -0,0 +1,67 @@
+package ed25519_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed25519"
+)
+
+func TestCtxVerifier(t *testing.T) {
+	const testTimes = 1 << 6
+	for _, ctx := range []string{"", strings.Repeat("c", ed25519.ContextMaxSize+1)} {
+		_, err := ed25519.NewCtxVerifier(ctx)
+		test.CheckIsErr(t, err, "should fail with bad context length")
+	}
+
+	ctx := "context"
+	v, err := ed25519.NewCtxVerifier(ctx)
+	test.CheckNoErr(t, err, "NewCtxVerifier failed")
+
+	msg := make([]byte, 64)
+	for i := 0; i < testTimes; i++ {
+		pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+		_, _ = rand.Read(msg)
+		sigs := [][]byte{
+			ed25519.SignWithCtx(priv, msg, ctx),
+			ed25519.SignWithCtx(priv, msg, "other context"),
+			ed25519.Sign(priv, msg),
+		}
+		bad := bytes.Clone(sigs[0])
+		bad[i%ed25519.SignatureSize] ^= 0x10
+		sigs = append(sigs, bad)
+
+		for _, sig := range sigs {
+			got := v.Verify(pub, msg, sig)
+			want := ed25519.VerifyWithCtx(pub, msg, sig, ctx)
+			if got != want {
+				test.ReportError(t, got, want, pub, msg, sig)
+			}
+		}
+	}
+}
+
+func BenchmarkCtxVerifier(b *testing.B) {
+	ctx := strings.Repeat("c", ed25519.ContextMaxSize)
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	msg := []byte("Hello, world!")
+	sig := ed25519.SignWithCtx(priv, msg, ctx)
+	v, err := ed25519.NewCtxVerifier(ctx)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("VerifyWithCtx", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ed25519.VerifyWithCtx(pub, msg, sig, ctx)
+		}
+	})
+	b.Run("CtxVerifier", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			v.Verify(pub, msg, sig)
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package ed25519
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/cryptobyte"
+	"golang.org/x/crypto/cryptobyte/asn1"
+)
+
+var (
+	errSignatureSize = errors.New("ed25519: bad signature length")
+	errSignatureDER  = errors.New("ed25519: malformed DER signature")
+)
+
+// MarshalSignatureDER wraps a raw signature in an ASN.1 OCTET STRING,
+//
+//	Ed25519Signature ::= OCTET STRING (SIZE (64))
+//
+// and returns its DER encoding. This is only meant for interoperability with
+// peers expecting such a wrapping; RFC 8410 and RFC 8032 use the raw
+// 64-byte signatures. It returns an error if len(sig) is not SignatureSize.
+func MarshalSignatureDER(sig []byte) ([]byte, error) {
+	if len(sig) != SignatureSize {
+		return nil, errSignatureSize
+	}
+	var b cryptobyte.Builder
+	b.AddASN1OctetString(sig)
+	return b.Bytes()
+}
+
+// ParseSignatureDER returns the raw signature wrapped in the DER encoding
+// produced by MarshalSignatureDER. It returns an error if der is not exactly
+// the DER encoding of an OCTET STRING of SignatureSize bytes.
+func ParseSignatureDER(der []byte) ([]byte, error) {
+	var sig []byte
+	s := cryptobyte.String(der)
+	if !s.ReadASN1Bytes(&sig, asn1.OCTET_STRING) || !s.Empty() {
+		return nil, errSignatureDER
+	}
+	if len(sig) != SignatureSize {
+		return nil, errSignatureSize
+	}
+	return append([]byte{}, sig...), nil
+}
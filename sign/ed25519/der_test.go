@@ -0,0 +1,50 @@
+package ed25519_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed25519"
+)
+
+func TestSignatureDER(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	test.CheckNoErr(t, err, "failed to generate key")
+	msg := []byte("message")
+	sig := ed25519.Sign(priv, msg)
+
+	der, err := ed25519.MarshalSignatureDER(sig)
+	test.CheckNoErr(t, err, "MarshalSignatureDER failed")
+	want := append([]byte{0x04, 0x40}, sig...)
+	if !bytes.Equal(der, want) {
+		test.ReportError(t, der, want)
+	}
+	got, err := ed25519.ParseSignatureDER(der)
+	test.CheckNoErr(t, err, "ParseSignatureDER failed")
+	if !bytes.Equal(got, sig) || !ed25519.Verify(pub, msg, got) {
+		test.ReportError(t, got, sig)
+	}
+
+	_, err = ed25519.MarshalSignatureDER(sig[:ed25519.SignatureSize-1])
+	test.CheckIsErr(t, err, "MarshalSignatureDER should fail on short signatures")
+
+	sigHex := hex.EncodeToString(sig)
+	for _, c := range []struct{ name, der string }{
+		{"empty", ""},
+		{"short", "043f" + sigHex[2:]},
+		{"long", "0441" + sigHex + "00"},
+		{"truncated", "0440" + sigHex[2:]},
+		{"trailing", "0440" + sigHex + "00"},
+		{"bit_string", "0340" + sigHex},
+		{"long_form_length", "048140" + sigHex},
+		{"indefinite_length", "0480" + sigHex + "0000"},
+		{"sequence", "3042" + "0440" + sigHex},
+	} {
+		der, _ := hex.DecodeString(c.der)
+		_, err := ed25519.ParseSignatureDER(der)
+		test.CheckIsErr(t, err, c.name)
+	}
+}
@@ -43,6 +43,7 @@ import (
 	"crypto/subtle"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"strconv"
 
@@ -66,6 +67,20 @@ const (
 	paramB = 256 / 8 // Size of keys in bytes.
 )
 
+// ValidSignatureLength reports whether sig has SignatureSize bytes. It does
+// not check that sig is a valid signature.
+func ValidSignatureLength(sig []byte) bool { return len(sig) == SignatureSize }
+
+// ValidPublicKeyLength reports whether pub has PublicKeySize bytes. It does
+// not check that pub encodes a point of the curve.
+func ValidPublicKeyLength(pub []byte) bool { return len(pub) == PublicKeySize }
+
+// ValidPrivateKeyLength reports whether priv has PrivateKeySize bytes, that
+// is, whether it is a seed followed by a public key.
+func ValidPrivateKeyLength(priv []byte) bool { return len(priv) == PrivateKeySize }
+
+var errBatchSize = errors.New("ed25519: negative number of keys")
+
 // SignerOptions implements crypto.SignerOpts and augments with parameters
 // that are specific to the Ed25519 signature schemes.
 type SignerOptions struct {
@@ -116,6 +131,24 @@ func (priv PrivateKey) Seed() []byte {
 	return seed
 }
 
+// Scalar returns the secret scalar a corresponding to priv, that is, the
+// first half of SHA-512(seed) clamped and reduced modulo the group order, in
+// little-endian order, so that the public key is [a]B. It is meant for
+// protocols that need further operations with a; as the seed, it must be
+// kept secret, and callers should overwrite it once they are done with it.
+func (priv PrivateKey) Scalar() []byte {
+	if l := len(priv); l != PrivateKeySize {
+		panic("ed25519: bad private key length: " + strconv.Itoa(l))
+	}
+	h := sha512.Sum512(priv[:SeedSize])
+	clamp(h[:])
+	reduceModOrder(h[:paramB], false)
+	s := make([]byte, paramB)
+	copy(s, h[:paramB])
+	h = [sha512.Size]byte{}
+	return s
+}
+
 func (priv PrivateKey) Scheme() sign.Scheme { return sch }
 
 func (pub PublicKey) Scheme() sign.Scheme { return sch }
@@ -174,7 +207,9 @@ func (priv PrivateKey) Sign(
 }
 
 // GenerateKey generates a public/private key pair using entropy from rand.
-// If rand is nil, crypto/rand.Reader will be used.
+// If rand is nil, crypto/rand.Reader will be used. If rand returns fewer than
+// SeedSize bytes, the error is io.ErrUnexpectedEOF, or the error returned by
+// rand, if any. No keys are returned on error.
 func GenerateKey(rand io.Reader) (PublicKey, PrivateKey, error) {
 	if rand == nil {
 		rand = cryptoRand.Reader
@@ -182,6 +217,10 @@ func GenerateKey(rand io.Reader) (PublicKey, PrivateKey, error) {
 
 	seed := make([]byte, SeedSize)
 	if _, err := io.ReadFull(rand, seed); err != nil {
+		// An empty reader is also a short read of the seed.
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
 		return nil, nil, err
 	}
 
@@ -192,6 +231,54 @@ func GenerateKey(rand io.Reader) (PublicKey, PrivateKey, error) {
 	return publicKey, privateKey, nil
 }
 
+// GenerateKeyBatch generates n private keys using entropy from rand. It
+// reads the n seeds with a single call to io.ReadFull, which is faster than
+// calling GenerateKey n times on a reader backed by a system call. The i-th
+// key is NewKeyFromSeed of the i-th SeedSize bytes read. If rand is nil,
+// crypto/rand.Reader will be used. On a short read, it returns
+// io.ErrUnexpectedEOF and no keys.
+func GenerateKeyBatch(rand io.Reader, n int) ([]PrivateKey, error) {
+	if n < 0 {
+		return nil, errBatchSize
+	}
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+
+	seeds := make([]byte, n*SeedSize)
+	if _, err := io.ReadFull(rand, seeds); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	keys := make([]PrivateKey, n)
+	for i := range keys {
+		keys[i] = NewKeyFromSeed(seeds[i*SeedSize : (i+1)*SeedSize])
+	}
+	return keys, nil
+}
+
+// SelectPrivateKey returns a copy of keys[secretIndex] without accessing keys
+// at a secret-dependent index. Every key is read, and the selected one is
+// copied with a constant-time mask, so the cost is O(len(keys)) and only
+// len(keys) is leaked through timing. It panics if secretIndex is out of
+// range or if a key is not PrivateKeySize bytes long.
+func SelectPrivateKey(keys []PrivateKey, secretIndex int) PrivateKey {
+	if secretIndex < 0 || secretIndex >= len(keys) {
+		panic("ed25519: key index out of range")
+	}
+	selected := make(PrivateKey, PrivateKeySize)
+	for i := range keys {
+		if l := len(keys[i]); l != PrivateKeySize {
+			panic("ed25519: bad private key length: " + strconv.Itoa(l))
+		}
+		subtle.ConstantTimeCopy(subtle.ConstantTimeEq(int32(i), int32(secretIndex)), selected, keys[i])
+	}
+	return selected
+}
+
 // NewKeyFromSeed calculates a private key from a seed. It will panic if
 // len(seed) is not SeedSize. This function is provided for interoperability
 // with RFC 8032. RFC 8032's private keys correspond to seeds in this
@@ -231,26 +318,57 @@ func signAll(signature []byte, privateKey PrivateKey, message, ctx []byte, preHa
 		PHM = message
 	}
 
+	var key expandedKey
+	key.expand(H, privateKey)
+	key.sign(H, signature, PHM, ctx, preHash)
+}
+
+// expandedKey holds the secret scalar and the prefix derived from the seed
+// of a private key, so that several messages can be signed hashing the seed
+// only once. It also holds scratch buffers for the digests.
+type expandedKey struct {
+	s, prefix [paramB]byte
+	public    []byte
+	r, hRAM   [2 * paramB]byte
+}
+
+// expand derives key from privateKey using H, which is reset first.
+func (key *expandedKey) expand(H hash.Hash, privateKey PrivateKey) {
 	// 1.  Hash the 32-byte private key using SHA-512.
+	h := key.r[:]
+	H.Reset()
 	_, _ = H.Write(privateKey[:SeedSize])
-	h := H.Sum(nil)
+	H.Sum(h[:0])
 	clamp(h[:])
-	prefix, s := h[paramB:], h[:paramB]
+	copy(key.s[:], h[:paramB])
+	copy(key.prefix[:], h[paramB:])
+	key.public = privateKey[SeedSize:]
+}
 
+// sign writes the signature of PH(M) to signature using the table of
+// multiples of the generator, and H as scratch.
+func (key *expandedKey) sign(H hash.Hash, signature, PHM, ctx []byte, preHash bool) {
 	// 2.  Compute SHA-512(dom2(F, C) || prefix || PH(M))
 	H.Reset()
 
 	writeDom(H, ctx, preHash)
 
-	_, _ = H.Write(prefix)
+	_, _ = H.Write(key.prefix[:])
 	_, _ = H.Write(PHM)
-	r := H.Sum(nil)
-	reduceModOrder(r[:], true)
+	H.Sum(key.r[:0])
+	key.finish(H, signature, nil, PHM, ctx, preHash)
+}
+
+// finish completes the signature of PH(M) = head || tail, once the digest
+// of step 2 is in key.r.
+func (key *expandedKey) finish(H hash.Hash, signature, head, tail, ctx []byte, preHash bool) {
+	r, hRAM := key.r[:], key.hRAM[:]
+	reduceModOrder(r, true)
 
 	// 3.  Compute the point [r]B.
 	var P pointR1
 	P.fixedMult(r[:paramB])
-	R := (&[paramB]byte{})[:]
+	R := signature[:paramB]
 	if err := P.ToBytes(R); err != nil {
 		panic(err)
 	}
@@ -261,19 +379,16 @@ func signAll(signature []byte, privateKey PrivateKey, message, ctx []byte, preHa
 	writeDom(H, ctx, preHash)
 
 	_, _ = H.Write(R)
-	_, _ = H.Write(privateKey[SeedSize:])
-	_, _ = H.Write(PHM)
-	hRAM := H.Sum(nil)
+	_, _ = H.Write(key.public)
+	_, _ = H.Write(head)
+	_, _ = H.Write(tail)
+	H.Sum(hRAM[:0])
 
-	reduceModOrder(hRAM[:], true)
+	reduceModOrder(hRAM, true)
 
 	// 5.  Compute S = (r + k * s) mod order.
-	S := (&[paramB]byte{})[:]
-	calculateS(S, r[:paramB], hRAM[:paramB], s)
-
 	// 6.  The signature is the concatenation of R and S.
-	copy(signature[:paramB], R[:])
-	copy(signature[paramB:], S[:])
+	calculateS(signature[paramB:SignatureSize], r[:paramB], hRAM[:paramB], key.s[:])
 }
 
 // Sign signs the message with privateKey and returns a signature.
@@ -320,6 +435,51 @@ func SignWithCtx(privateKey PrivateKey, message []byte, ctx string) []byte {
 }
 
 func verify(public PublicKey, message, signature, ctx []byte, preHash bool) bool {
+	var PHM []byte
+	if preHash {
+		h := sha512.Sum512(message)
+		PHM = h[:]
+	} else {
+		PHM = message
+	}
+	return verifyPHM(public, PHM, signature, ctx, preHash)
+}
+
+// verifyPHM verifies a signature of PH(M), where PH is the identity function
+// for Ed25519 and Ed25519ctx, and SHA-512 for Ed25519ph.
+func verifyPHM(public PublicKey, PHM, signature, ctx []byte, preHash bool) bool {
+	var v VerifyContext
+	return v.verifyPHM(public, PHM, signature, ctx, preHash)
+}
+
+// VerifyContext holds a reusable SHA-512 state and scratch buffers, so that
+// verifying signatures with it does not allocate memory, except on its first
+// use. The zero value is ready to use. A VerifyContext must not be used
+// concurrently by multiple goroutines.
+type VerifyContext struct {
+	h    hash.Hash
+	hRAM [2 * paramB]byte
+	encR [paramB]byte
+}
+
+// Verify returns true if the signature is valid. It is equivalent to the
+// Verify function of this package.
+func (v *VerifyContext) Verify(public PublicKey, message, signature []byte) bool {
+	return v.verifyPHM(public, message, signature, nil, false)
+}
+
+func (v *VerifyContext) verifyPHM(public PublicKey, PHM, signature, ctx []byte, preHash bool) bool {
+	if v.h == nil {
+		v.h = sha512.New()
+	}
+	v.h.Reset()
+	writeDom(v.h, ctx, preHash)
+	return v.verifyDom(public, PHM, signature, v.h)
+}
+
+// verifyDom is as verifyPHM, except that H must have already absorbed the
+// dom2 prefix.
+func (v *VerifyContext) verifyDom(public PublicKey, PHM, signature []byte, H hash.Hash) bool {
 	if len(public) != PublicKeySize ||
 		len(signature) != SignatureSize ||
 		!isLessThanOrder(signature[paramB:]) {
@@ -331,31 +491,24 @@ func verify(public PublicKey, message, signature, ctx []byte, preHash bool) bool
 		return false
 	}
 
-	H := sha512.New()
-	var PHM []byte
-
-	if preHash {
-		_, _ = H.Write(message)
-		PHM = H.Sum(nil)
-		H.Reset()
-	} else {
-		PHM = message
-	}
-
 	R := signature[:paramB]
-
-	writeDom(H, ctx, preHash)
-
 	_, _ = H.Write(R)
 	_, _ = H.Write(public)
 	_, _ = H.Write(PHM)
-	hRAM := H.Sum(nil)
+	hRAM := H.Sum(v.hRAM[:0])
 	reduceModOrder(hRAM[:], true)
 
+	return checkEquation(&P, R, signature[paramB:], hRAM[:paramB], v.encR[:])
+}
+
+// checkEquation returns true if [S]B = R + [c]A, where A is the point P.
+// It overwrites P and encR. Comparing encodings costs one inversion in
+// ToBytes; a projective comparison against R would not be cheaper, as
+// decoding R takes a square root, which costs as much as the inversion.
+func checkEquation(P *pointR1, R, S, c, encR []byte) bool {
 	var Q pointR1
-	encR := (&[paramB]byte{})[:]
 	P.neg()
-	Q.doubleMult(&P, signature[paramB:], hRAM[:paramB])
+	Q.doubleMult(P, S, c)
 	_ = Q.ToBytes(encR)
 	return bytes.Equal(R, encR)
 }
@@ -421,6 +574,28 @@ func VerifyWithCtx(public PublicKey, message, signature []byte, ctx string) bool
 	return verify(public, message, signature, []byte(ctx), false)
 }
 
+// VerifyChallenge returns true if [S]B = R + [challenge]A, where A is the
+// public key. It is meant for protocols in which the challenge H(R||A||M)
+// is computed by another party, so no hashing is performed here. The
+// challenge and S must be canonical scalars, i.e., 32 bytes encoding, in
+// little-endian order, a number less than the order. Failure cases are as
+// for Verify, or when the challenge is not canonical.
+func VerifyChallenge(public PublicKey, R, S, challenge []byte) bool {
+	if len(public) != PublicKeySize ||
+		len(R) != paramB || len(S) != paramB || len(challenge) != paramB ||
+		!isLessThanOrder(S) || !isLessThanOrder(challenge) {
+		return false
+	}
+
+	var P pointR1
+	if ok := P.FromBytes(public); !ok {
+		return false
+	}
+
+	var encR [paramB]byte
+	return checkEquation(&P, R, S, challenge, encR[:])
+}
+
 func clamp(k []byte) {
 	k[0] &= 248
 	k[paramB-1] = (k[paramB-1] & 127) | 64
@@ -57,6 +57,38 @@ func TestPublic(t *testing.T) {
 	}
 }
 
+func TestSizes(t *testing.T) {
+	if ed25519.SignatureSize != 64 || ed25519.SeedSize != 32 ||
+		ed25519.PublicKeySize != 32 || ed25519.PrivateKeySize != 64 {
+		t.Fatal("unexpected size constants")
+	}
+
+	var zero zeroReader
+	pub, priv, err := ed25519.GenerateKey(zero)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := ed25519.Sign(priv, []byte("message"))
+	if len(sig) != ed25519.SignatureSize || !ed25519.ValidSignatureLength(sig) {
+		t.Fatalf("got signature length %v, want %v", len(sig), ed25519.SignatureSize)
+	}
+	if !ed25519.ValidPublicKeyLength(pub) || !ed25519.ValidPrivateKeyLength(priv) {
+		t.Fatal("generated keys must have valid lengths")
+	}
+	if len(priv.Seed()) != ed25519.SeedSize {
+		t.Fatalf("got seed length %v, want %v", len(priv.Seed()), ed25519.SeedSize)
+	}
+
+	for _, n := range []int{0, 31, 33, 63, 65} {
+		b := make([]byte, n)
+		if ed25519.ValidSignatureLength(b) ||
+			ed25519.ValidPublicKeyLength(b) ||
+			ed25519.ValidPrivateKeyLength(b) {
+			t.Fatalf("length %v must not be valid", n)
+		}
+	}
+}
+
 func BenchmarkKeyGeneration(b *testing.B) {
 	var zero zeroReader
 	for i := 0; i < b.N; i++ {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto"
 	"crypto/rand"
+	"crypto/sha512"
 	"errors"
 	"fmt"
 	"testing"
@@ -140,6 +141,51 @@ func TestErrors(t *testing.T) {
 	})
 }
 
+func TestVerifyChallenge(t *testing.T) {
+	const testTimes = 1 << 6
+	order := []byte{
+		0xed, 0xd3, 0xf5, 0x5c, 0x1a, 0x63, 0x12, 0x58,
+		0xd6, 0x9c, 0xf7, 0xa2, 0xde, 0xf9, 0xde, 0x14,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+	}
+	msg := make([]byte, 64)
+	for i := 0; i < testTimes; i++ {
+		pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+		_, _ = rand.Read(msg)
+		sig := ed25519.Sign(priv, msg)
+		R, S := sig[:ed25519.ScalarSize], sig[ed25519.ScalarSize:]
+
+		var c ed25519.Scalar
+		h := sha512.New()
+		_, _ = h.Write(R)
+		_, _ = h.Write(pub)
+		_, _ = h.Write(msg)
+		test.CheckNoErr(t, c.SetUniformBytes(h.Sum(nil)), "SetUniformBytes failed")
+		challenge := c.Bytes()
+
+		got := ed25519.VerifyChallenge(pub, R, S, challenge)
+		want := ed25519.Verify(pub, msg, sig)
+		if got != want || !got {
+			test.ReportError(t, got, want, pub, msg, sig)
+		}
+
+		var one ed25519.Scalar
+		_ = one.SetBytes(append([]byte{1}, make([]byte, ed25519.ScalarSize-1)...))
+		c.Add(&c, &one)
+		got = ed25519.VerifyChallenge(pub, R, S, c.Bytes())
+		want = false
+		if got != want {
+			test.ReportError(t, got, want, pub, msg, sig)
+		}
+
+		// Non-canonical challenge and bad lengths.
+		test.CheckOk(!ed25519.VerifyChallenge(pub, R, S, order), "non-canonical challenge accepted", t)
+		test.CheckOk(!ed25519.VerifyChallenge(pub, R, S, challenge[:16]), "short challenge accepted", t)
+		test.CheckOk(!ed25519.VerifyChallenge(pub, R[:16], S, challenge), "short R accepted", t)
+	}
+}
+
 func BenchmarkEd25519Ph(b *testing.B) {
 	msg := make([]byte, 128)
 	_, _ = rand.Read(msg)
@@ -0,0 +1,35 @@
+package ed25519
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// NewKeyFromRoot derives a private key for the purpose identified by label
+// from a root secret. The seed of the key is the output of HKDF-SHA512
+// (RFC 5869) with root as input keying material, no salt, and label as
+// info, so keys derived for different labels are independent. The root
+// must have enough entropy, e.g., at least SeedSize random bytes.
+func NewKeyFromRoot(root []byte, label string) PrivateKey {
+	seed := make([]byte, SeedSize)
+	r := hkdf.New(sha512.New, root, nil, []byte(label))
+	if _, err := io.ReadFull(r, seed); err != nil {
+		panic(err)
+	}
+	return NewKeyFromSeed(seed)
+}
+
+// DeterministicKeyPair returns the private key whose seed is the first
+// SeedSize bytes of SHA-512(index), where index is encoded as 8 bytes in
+// big-endian order. It is meant for generating reproducible keys for tests
+// and fuzzing; since the seeds are public, these keys must not be used
+// otherwise.
+func DeterministicKeyPair(index uint64) PrivateKey {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], index)
+	h := sha512.Sum512(b[:])
+	return NewKeyFromSeed(h[:SeedSize])
+}
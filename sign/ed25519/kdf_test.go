@@ -0,0 +1,89 @@
+package ed25519_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha512"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed25519"
+)
+
+// hkdfSHA512 computes the first block of HKDF-SHA512 following RFC 5869.
+func hkdfSHA512(ikm, salt, info []byte) []byte {
+	if salt == nil {
+		salt = make([]byte, sha512.Size)
+	}
+	extract := hmac.New(sha512.New, salt)
+	_, _ = extract.Write(ikm)
+	prk := extract.Sum(nil)
+	expand := hmac.New(sha512.New, prk)
+	_, _ = expand.Write(info)
+	_, _ = expand.Write([]byte{0x01})
+	return expand.Sum(nil)
+}
+
+func TestNewKeyFromRoot(t *testing.T) {
+	root := []byte("an example root secret of 32 bytes")
+	labels := []string{"", "tls", "code-signing", "code-signing "}
+
+	keys := make([]ed25519.PrivateKey, len(labels))
+	for i, label := range labels {
+		keys[i] = ed25519.NewKeyFromRoot(root, label)
+
+		again := ed25519.NewKeyFromRoot(root, label)
+		if !bytes.Equal(keys[i], again) {
+			test.ReportError(t, again, keys[i], root, label)
+		}
+
+		seed := hkdfSHA512(root, nil, []byte(label))[:ed25519.SeedSize]
+		want := ed25519.NewKeyFromSeed(seed)
+		if !bytes.Equal(keys[i], want) {
+			test.ReportError(t, keys[i], want, root, label)
+		}
+
+		for j := 0; j < i; j++ {
+			if bytes.Equal(keys[i], keys[j]) {
+				t.Fatalf("same key for labels %q and %q", labels[i], labels[j])
+			}
+		}
+	}
+
+	other := ed25519.NewKeyFromRoot(append(root, 0), labels[1])
+	if bytes.Equal(other, keys[1]) {
+		t.Fatal("same key for different roots")
+	}
+}
+
+func TestDeterministicKeyPair(t *testing.T) {
+	const n = 1 << 8
+	seen := make(map[string]uint64, n)
+	msg := []byte("message")
+	indices := []uint64{1<<64 - 1}
+	for i := uint64(0); i < n; i++ {
+		indices = append(indices, i)
+	}
+	for _, index := range indices {
+		key := ed25519.DeterministicKeyPair(index)
+		if again := ed25519.DeterministicKeyPair(index); !bytes.Equal(key, again) {
+			test.ReportError(t, again, key, index)
+		}
+		if prev, ok := seen[string(key)]; ok {
+			test.ReportError(t, index, prev)
+		}
+		seen[string(key)] = index
+
+		pub := key.Public().(ed25519.PublicKey)
+		if !ed25519.Verify(pub, msg, ed25519.Sign(key, msg)) {
+			test.ReportError(t, false, true, index)
+		}
+	}
+
+	// The seed of the key for index 1 is SHA-512(00 00 00 00 00 00 00 01).
+	h := sha512.Sum512([]byte{0, 0, 0, 0, 0, 0, 0, 1})
+	got := ed25519.DeterministicKeyPair(1).Seed()
+	if want := h[:ed25519.SeedSize]; !bytes.Equal(got, want) {
+		test.ReportError(t, got, want)
+	}
+}
@@ -0,0 +1,121 @@
+package ed25519_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed25519"
+)
+
+func TestGenerateKeyShortRead(t *testing.T) {
+	errRead := errors.New("read failed")
+	seed := bytes.Repeat([]byte{0x5A}, ed25519.SeedSize)
+	for _, c := range []struct {
+		name string
+		r    io.Reader
+		want error
+	}{
+		{"empty", bytes.NewReader(nil), io.ErrUnexpectedEOF},
+		{"short", bytes.NewReader(seed[:ed25519.SeedSize-1]), io.ErrUnexpectedEOF},
+		{"error", iotest.ErrReader(errRead), errRead},
+		{"partialThenError", io.MultiReader(bytes.NewReader(seed[:7]), iotest.ErrReader(errRead)), errRead},
+		{"oneByteAtATime", iotest.OneByteReader(bytes.NewReader(seed)), nil},
+	} {
+		pub, priv, err := ed25519.GenerateKey(c.r)
+		if !errors.Is(err, c.want) {
+			test.ReportError(t, err, c.want, c.name)
+		}
+		if c.want != nil && (pub != nil || priv != nil) {
+			test.ReportError(t, priv, nil, c.name)
+		}
+		if c.want == nil && !bytes.Equal(priv, ed25519.NewKeyFromSeed(seed)) {
+			test.ReportError(t, priv, ed25519.NewKeyFromSeed(seed), c.name)
+		}
+	}
+}
+
+func FuzzGenerateKey(f *testing.F) {
+	f.Add([]byte{})
+	f.Add(make([]byte, ed25519.SeedSize-1))
+	f.Add(make([]byte, ed25519.SeedSize))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		pub, priv, err := ed25519.GenerateKey(bytes.NewReader(data))
+		if len(data) < ed25519.SeedSize {
+			if err != io.ErrUnexpectedEOF || pub != nil || priv != nil {
+				t.Fatalf("got (%x, %x, %v) for a %v-byte reader", pub, priv, err, len(data))
+			}
+			return
+		}
+		test.CheckNoErr(t, err, "GenerateKey failed")
+		if want := ed25519.NewKeyFromSeed(data[:ed25519.SeedSize]); !bytes.Equal(priv, want) {
+			test.ReportError(t, priv, want, data)
+		}
+		if !bytes.Equal(pub, priv[ed25519.SeedSize:]) {
+			test.ReportError(t, pub, priv[ed25519.SeedSize:], data)
+		}
+	})
+}
+
+func TestGenerateKeyBatch(t *testing.T) {
+	const n = 5
+	entropy := make([]byte, n*ed25519.SeedSize)
+	for i := range entropy {
+		entropy[i] = byte(i)
+	}
+
+	keys, err := ed25519.GenerateKeyBatch(bytes.NewReader(entropy), n)
+	test.CheckNoErr(t, err, "GenerateKeyBatch failed")
+	if len(keys) != n {
+		test.ReportError(t, len(keys), n)
+	}
+	for i, got := range keys {
+		want := ed25519.NewKeyFromSeed(entropy[i*ed25519.SeedSize : (i+1)*ed25519.SeedSize])
+		if !bytes.Equal(got, want) {
+			test.ReportError(t, got, want, i)
+		}
+	}
+
+	keys, err = ed25519.GenerateKeyBatch(bytes.NewReader(entropy[:len(entropy)-1]), n)
+	if err != io.ErrUnexpectedEOF || keys != nil {
+		test.ReportError(t, err, io.ErrUnexpectedEOF, keys)
+	}
+	keys, err = ed25519.GenerateKeyBatch(bytes.NewReader(nil), n)
+	if err != io.ErrUnexpectedEOF || keys != nil {
+		test.ReportError(t, err, io.ErrUnexpectedEOF, keys)
+	}
+	keys, err = ed25519.GenerateKeyBatch(nil, 0)
+	if err != nil || len(keys) != 0 {
+		test.ReportError(t, err, nil, keys)
+	}
+	_, err = ed25519.GenerateKeyBatch(nil, -1)
+	test.CheckIsErr(t, err, "GenerateKeyBatch should fail on negative n")
+}
+
+func TestSelectPrivateKey(t *testing.T) {
+	keys, err := ed25519.GenerateKeyBatch(nil, 5)
+	test.CheckNoErr(t, err, "GenerateKeyBatch failed")
+	msg := []byte("message")
+	for i := range keys {
+		got := ed25519.SelectPrivateKey(keys, i)
+		if !bytes.Equal(got, keys[i]) {
+			test.ReportError(t, got, keys[i], i)
+		}
+		if !ed25519.Verify(keys[i].Public().(ed25519.PublicKey), msg, ed25519.Sign(got, msg)) {
+			test.ReportError(t, false, true, i)
+		}
+	}
+	got := ed25519.SelectPrivateKey(keys, 0)
+	got[0] ^= 1
+	if bytes.Equal(got, keys[0]) {
+		t.Error("SelectPrivateKey must return a copy")
+	}
+
+	for _, i := range []int{-1, len(keys)} {
+		err := test.CheckPanic(func() { ed25519.SelectPrivateKey(keys, i) })
+		test.CheckNoErr(t, err, "SelectPrivateKey should panic on a bad index")
+	}
+}
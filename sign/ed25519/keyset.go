@@ -0,0 +1,59 @@
+package ed25519
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// KeySet verifies signatures against a set of public keys, each one valid
+// during a window of time, such as the keys of an issuer that rotates them
+// and keeps accepting the previous key for a grace period.
+//
+// The zero value is an empty KeySet ready to use. A KeySet is safe for
+// concurrent use by multiple goroutines.
+type KeySet struct {
+	mu   sync.RWMutex
+	keys []keySetEntry
+}
+
+type keySetEntry struct {
+	public                PublicKey
+	validFrom, validUntil time.Time
+}
+
+// Add adds public to the set, valid at the times t such that
+// validFrom <= t < validUntil. It panics if len(public) is not
+// PublicKeySize.
+func (s *KeySet) Add(public PublicKey, validFrom, validUntil time.Time) {
+	if l := len(public); l != PublicKeySize {
+		panic("ed25519: bad public key length: " + strconv.Itoa(l))
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = append(s.keys, keySetEntry{
+		public:     append(PublicKey(nil), public...),
+		validFrom:  validFrom,
+		validUntil: validUntil,
+	})
+}
+
+// Verify returns true and the key that validates the signature of message,
+// as Verify does, among the keys valid at the given time. Keys are tried
+// from the most recently added one, so that the current key is tried before
+// the ones in a grace period. It returns false and a nil key if no key is
+// valid at that time, or if none of them validates the signature.
+func (s *KeySet) Verify(message, sig []byte, at time.Time) (ok bool, matchedKey PublicKey) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for i := len(s.keys) - 1; i >= 0; i-- {
+		k := &s.keys[i]
+		if at.Before(k.validFrom) || !at.Before(k.validUntil) {
+			continue
+		}
+		if Verify(k.public, message, sig) {
+			return true, append(PublicKey(nil), k.public...)
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,57 @@
+package ed25519_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed25519"
+)
+
+func TestKeySet(t *testing.T) {
+	prevPub, prevPriv, err := ed25519.GenerateKey(rand.Reader)
+	test.CheckNoErr(t, err, "GenerateKey failed")
+	curPub, curPriv, err := ed25519.GenerateKey(rand.Reader)
+	test.CheckNoErr(t, err, "GenerateKey failed")
+
+	// The previous key is valid in [t0, t2), and the current one from t1,
+	// so [t1, t2) is the grace period.
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(30 * 24 * time.Hour)
+	t2 := t1.Add(7 * 24 * time.Hour)
+	t3 := t1.Add(60 * 24 * time.Hour)
+
+	var s ed25519.KeySet
+	s.Add(prevPub, t0, t2)
+	s.Add(curPub, t1, t3)
+
+	msg := []byte("message")
+	prevSig := ed25519.Sign(prevPriv, msg)
+	curSig := ed25519.Sign(curPriv, msg)
+
+	for _, c := range []struct {
+		sig  []byte
+		at   time.Time
+		want ed25519.PublicKey
+	}{
+		{prevSig, t0, prevPub},
+		{prevSig, t1.Add(time.Hour), prevPub}, // Grace period.
+		{prevSig, t2, nil},                    // Grace period is over.
+		{curSig, t0, nil},                     // Not valid yet.
+		{curSig, t1, curPub},
+		{curSig, t2, curPub},
+		{curSig, t3, nil},                  // Outside all windows.
+		{prevSig, t0.Add(-time.Hour), nil}, // Outside all windows.
+		{curSig[:10], t1, nil},
+	} {
+		ok, got := s.Verify(msg, c.sig, c.at)
+		if ok != (c.want != nil) || !bytes.Equal(got, c.want) {
+			test.ReportError(t, got, c.want, c.at)
+		}
+	}
+
+	err = test.CheckPanic(func() { s.Add(curPub[:10], t0, t1) })
+	test.CheckNoErr(t, err, "Add must panic on bad key length")
+}
@@ -0,0 +1,85 @@
+package ed25519
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+)
+
+const (
+	merkleLeafTag     = 0x00
+	merkleInteriorTag = 0x01
+	merkleRootTag     = 0x02
+	merklePadTag      = 0x03
+)
+
+// MerkleRoot computes the root of the binary Merkle tree whose leaves are
+// given by chunkHashes, using the domain string for domain separation.
+// It returns nil if chunkHashes is empty or the domain length is not in
+// the range [1, ContextMaxSize].
+//
+// The tree is constructed as follows, where H is SHA-512 and
+// prefix = byte(len(domain)) || domain:
+//
+//	leaf     = H(prefix || 0x00 || chunkHash)
+//	interior = H(prefix || 0x01 || left || right)
+//	root     = H(prefix || 0x02 || n || top)
+//
+// Leaves are paired from left to right. At each level with an odd number of
+// nodes, the last node is duplicated, i.e., it is paired with itself. The
+// node remaining at the top level is bound to the number of chunks n, encoded
+// as a 64-bit big-endian integer, so that duplicating the last chunks does
+// not yield the same root.
+func MerkleRoot(chunkHashes [][]byte, domain string) []byte {
+	if len(chunkHashes) == 0 || len(domain) == 0 || len(domain) > ContextMaxSize {
+		return nil
+	}
+
+	prefix := append([]byte{byte(len(domain))}, domain...)
+	H := sha512.New()
+	level := make([][]byte, len(chunkHashes))
+	for i := range chunkHashes {
+		H.Reset()
+		_, _ = H.Write(prefix)
+		_, _ = H.Write([]byte{merkleLeafTag})
+		_, _ = H.Write(chunkHashes[i])
+		level[i] = H.Sum(nil)
+	}
+
+	for len(level) > 1 {
+		if len(level)%2 == 1 {
+			level = append(level, level[len(level)-1])
+		}
+		for i := 0; i < len(level)/2; i++ {
+			H.Reset()
+			_, _ = H.Write(prefix)
+			_, _ = H.Write([]byte{merkleInteriorTag})
+			_, _ = H.Write(level[2*i])
+			_, _ = H.Write(level[2*i+1])
+			level[i] = H.Sum(nil)
+		}
+		level = level[:len(level)/2]
+	}
+
+	var n [8]byte
+	binary.BigEndian.PutUint64(n[:], uint64(len(chunkHashes)))
+	H.Reset()
+	_, _ = H.Write(prefix)
+	_, _ = H.Write([]byte{merkleRootTag})
+	_, _ = H.Write(n[:])
+	_, _ = H.Write(level[0])
+	return H.Sum(nil)
+}
+
+// VerifyMerkleSigned returns true if signature is a valid Ed25519ctx
+// signature, under the public key and using domain as context, of the
+// Merkle root computed by MerkleRoot from chunkHashes and domain.
+// Failure cases are an empty list of chunks, a domain not suitable as a
+// context string, an invalid signature, or when the public key cannot be
+// decoded.
+func VerifyMerkleSigned(public PublicKey, chunkHashes [][]byte, signature []byte, domain string) bool {
+	root := MerkleRoot(chunkHashes, domain)
+	if root == nil {
+		return false
+	}
+	return VerifyWithCtx(public, root, signature, domain)
+}
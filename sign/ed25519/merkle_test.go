@@ -0,0 +1,68 @@
+package ed25519_test
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed25519"
+)
+
+func TestMerkleSigned(t *testing.T) {
+	const domain = "merkle test"
+	chunks := []string{"chunk0", "chunk1", "chunk2", "chunk3", "chunk4"}
+	chunkHashes := make([][]byte, len(chunks))
+	for i := range chunks {
+		h := sha256.Sum256([]byte(chunks[i]))
+		chunkHashes[i] = h[:]
+	}
+
+	t.Run("knownRoot", func(t *testing.T) {
+		got := hex.EncodeToString(ed25519.MerkleRoot(chunkHashes, domain))
+		want := "0d6966dfdb52168e69323a6ca159119fc4009ac5ec0b78c5125d764d0f1806ae" +
+			"95be3d41a2f9e44ebf465046f471cc15d7af1baaac1bcdc9e7b63043be7ed3f6"
+		if got != want {
+			test.ReportError(t, got, want)
+		}
+	})
+
+	t.Run("verify", func(t *testing.T) {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		test.CheckNoErr(t, err, "GenerateKey failed")
+		root := ed25519.MerkleRoot(chunkHashes, domain)
+		sig := ed25519.SignWithCtx(priv, root, domain)
+
+		got := ed25519.VerifyMerkleSigned(pub, chunkHashes, sig, domain)
+		want := true
+		if got != want {
+			test.ReportError(t, got, want)
+		}
+
+		tampered := make([][]byte, len(chunkHashes))
+		copy(tampered, chunkHashes)
+		tampered[3] = append([]byte{}, chunkHashes[3]...)
+		tampered[3][0] ^= 0x01
+		// Duplicating the last chunk must not yield the same root.
+		duplicated := append(append([][]byte{}, chunkHashes...), chunkHashes[4])
+
+		for _, c := range []struct {
+			chunks [][]byte
+			domain string
+		}{
+			{tampered, domain},
+			{duplicated, domain},
+			{chunkHashes[:4], domain},
+			{chunkHashes, "another domain"},
+			{nil, domain},
+			{chunkHashes, ""},
+		} {
+			got := ed25519.VerifyMerkleSigned(pub, c.chunks, sig, c.domain)
+			want := false
+			if got != want {
+				test.ReportError(t, got, want, c.domain, len(c.chunks))
+			}
+		}
+	})
+}
@@ -34,6 +34,23 @@ func reduceModOrder(k []byte, is512Bit bool) {
 	}
 }
 
+// ReduceWide returns the canonical reduction modulo the order of the curve
+// of k, which is the encoding of an integer in little-endian order of at
+// most 64 bytes. Inputs shorter than 64 bytes are zero-padded, that is,
+// they encode the same integer. The result is ScalarSize bytes in
+// little-endian order. It panics if k is longer than 64 bytes.
+func ReduceWide(k []byte) [ScalarSize]byte {
+	if len(k) > 2*paramB {
+		panic(errUniformBytesLen)
+	}
+	var x [2 * paramB]byte
+	var s [ScalarSize]byte
+	copy(x[:], k)
+	reduceModOrder(x[:], true)
+	copy(s[:], x[:paramB])
+	return s
+}
+
 // red512 calculates x = x mod Order of the curve.
 func red512(x *[8]uint64, full bool) {
 	// Implementation of Algs.(14.47)+(14.52) of Handbook of Applied
@@ -128,7 +145,15 @@ func red512(x *[8]uint64, full bool) {
 	r0, c0 = bits.Sub64(r0, s0, 0)
 	r1, c1 = bits.Sub64(r1, s1, c0)
 	r2, c2 = bits.Sub64(r2, s2, c1)
-	r3, _ = bits.Sub64(r3, 0, c2)
+	r3, c3 = bits.Sub64(r3, 0, c2)
+
+	// The result is negative if the low 252 bits were less than q0*ell, in
+	// which case the order is added.
+	m := -c3
+	r0, c0 = bits.Add64(r0, m&ell0, 0)
+	r1, c1 = bits.Add64(r1, m&ell1, c0)
+	r2, c2 = bits.Add64(r2, 0, c1)
+	r3, _ = bits.Add64(r3, m&(uint64(1)<<60), c2)
 
 	x[0], x[1], x[2], x[3] = r0, r1, r2, r3
 }
@@ -167,9 +192,142 @@ func calculateS(s, r, k, a []byte) {
 		S[i+3], c3 = bits.Add64(S[i+3], l3, c2)
 		S[i+4], _ = bits.Add64(S[i+4], l4, c3)
 	}
-	red512(&S, true)
+	redMulAdd(&S)
 	binary.LittleEndian.PutUint64(s[0*8:1*8], S[0])
 	binary.LittleEndian.PutUint64(s[1*8:2*8], S[1])
 	binary.LittleEndian.PutUint64(s[2*8:3*8], S[2])
 	binary.LittleEndian.PutUint64(s[3*8:4*8], S[3])
 }
+
+// redMulAdd calculates x = x mod Order of the curve as red512 does, but
+// each folding step only multiplies the words of the quotient that can be
+// non-zero: for x < 2^512, the quotients are less than 2^256, 2^129 and 4.
+func redMulAdd(x *[8]uint64) {
+	const (
+		ell0   = uint64(0x5812631a5cf5d3ed)
+		ell1   = uint64(0x14def9dea2f79cd6)
+		ell160 = uint64(0x812631a5cf5d3ed0)
+		ell161 = uint64(0x4def9dea2f79cd65)
+		ell162 = uint64(0x0000000000000001)
+	)
+	var c0, c1, c2, c3 uint64
+	r0, r1, r2, r3, r4 := x[0], x[1], x[2], x[3], uint64(0)
+	q0, q1, q2, q3 := x[4], x[5], x[6], x[7]
+
+	{ // r = r - q*16*ell, with q < 2^256.
+		h0, s0 := bits.Mul64(q0, ell160)
+		h1, s1 := bits.Mul64(q1, ell160)
+		h2, s2 := bits.Mul64(q2, ell160)
+		h3, s3 := bits.Mul64(q3, ell160)
+
+		s1, c0 = bits.Add64(h0, s1, 0)
+		s2, c1 = bits.Add64(h1, s2, c0)
+		s3, c2 = bits.Add64(h2, s3, c1)
+		s4, _ := bits.Add64(h3, 0, c2)
+
+		h0, l0 := bits.Mul64(q0, ell161)
+		h1, l1 := bits.Mul64(q1, ell161)
+		h2, l2 := bits.Mul64(q2, ell161)
+		h3, l3 := bits.Mul64(q3, ell161)
+
+		l1, c0 = bits.Add64(h0, l1, 0)
+		l2, c1 = bits.Add64(h1, l2, c0)
+		l3, c2 = bits.Add64(h2, l3, c1)
+		l4, _ := bits.Add64(h3, 0, c2)
+
+		s1, c0 = bits.Add64(s1, l0, 0)
+		s2, c1 = bits.Add64(s2, l1, c0)
+		s3, c2 = bits.Add64(s3, l2, c1)
+		s4, c3 = bits.Add64(s4, l3, c2)
+		s5, s6 := bits.Add64(l4, 0, c3)
+
+		s2, c0 = bits.Add64(s2, q0, 0)
+		s3, c1 = bits.Add64(s3, q1, c0)
+		s4, c2 = bits.Add64(s4, q2, c1)
+		s5, c3 = bits.Add64(s5, q3, c2)
+		s6, _ = bits.Add64(s6, 0, c3)
+
+		r0, c0 = bits.Sub64(r0, s0, 0)
+		r1, c1 = bits.Sub64(r1, s1, c0)
+		r2, c2 = bits.Sub64(r2, s2, c1)
+		r3, c3 = bits.Sub64(r3, s3, c2)
+		r4, _ = bits.Sub64(r4, 0, c3)
+		q0, q1, q2 = s4, s5, s6
+	}
+
+	{ // r = r + q*16*ell, with q < 2^129.
+		h0, s0 := bits.Mul64(q0, ell160)
+		h1, s1 := bits.Mul64(q1, ell160)
+		h2, s2 := bits.Mul64(q2, ell160)
+
+		s1, c0 = bits.Add64(h0, s1, 0)
+		s2, c1 = bits.Add64(h1, s2, c0)
+		s3, _ := bits.Add64(h2, 0, c1)
+
+		h0, l0 := bits.Mul64(q0, ell161)
+		h1, l1 := bits.Mul64(q1, ell161)
+		h2, l2 := bits.Mul64(q2, ell161)
+
+		l1, c0 = bits.Add64(h0, l1, 0)
+		l2, c1 = bits.Add64(h1, l2, c0)
+		l3, _ := bits.Add64(h2, 0, c1)
+
+		s1, c0 = bits.Add64(s1, l0, 0)
+		s2, c1 = bits.Add64(s2, l1, c0)
+		s3, c2 = bits.Add64(s3, l2, c1)
+		s4, _ := bits.Add64(l3, 0, c2)
+
+		s2, c0 = bits.Add64(s2, q0, 0)
+		s3, c1 = bits.Add64(s3, q1, c0)
+		s4, _ = bits.Add64(s4, q2, c1)
+
+		r0, c0 = bits.Add64(r0, s0, 0)
+		r1, c1 = bits.Add64(r1, s1, c0)
+		r2, c2 = bits.Add64(r2, s2, c1)
+		r3, c3 = bits.Add64(r3, s3, c2)
+		r4, _ = bits.Add64(r4, 0, c3)
+		q0 = s4
+	}
+
+	{ // r = r - q*16*ell, with q < 4.
+		h0, s0 := bits.Mul64(q0, ell160)
+		h1, l1 := bits.Mul64(q0, ell161)
+		s1, c0 := bits.Add64(h0, l1, 0)
+		s2, _ := bits.Add64(h1, q0, c0)
+
+		r0, c0 = bits.Sub64(r0, s0, 0)
+		r1, c1 = bits.Sub64(r1, s1, c0)
+		r2, c2 = bits.Sub64(r2, s2, c1)
+		r3, c3 = bits.Sub64(r3, 0, c2)
+		r4, _ = bits.Sub64(r4, 0, c3)
+	}
+
+	m := -(r4 >> 63)
+	r0, c0 = bits.Add64(r0, m&ell160, 0)
+	r1, c1 = bits.Add64(r1, m&ell161, c0)
+	r2, c2 = bits.Add64(r2, m&ell162, c1)
+	r3, c3 = bits.Add64(r3, 0, c2)
+	r4, _ = bits.Add64(r4, m&1, c3)
+
+	q0 = (r4 << 4) | (r3 >> 60)
+	r3 &= (uint64(1) << 60) - 1
+
+	h0, s0 := bits.Mul64(ell0, q0)
+	h1, s1 := bits.Mul64(ell1, q0)
+	s1, c0 = bits.Add64(h0, s1, 0)
+	s2, _ := bits.Add64(h1, 0, c0)
+
+	r0, c0 = bits.Sub64(r0, s0, 0)
+	r1, c1 = bits.Sub64(r1, s1, c0)
+	r2, c2 = bits.Sub64(r2, s2, c1)
+	r3, c3 = bits.Sub64(r3, 0, c2)
+
+	m = -c3
+	r0, c0 = bits.Add64(r0, m&ell0, 0)
+	r1, c1 = bits.Add64(r1, m&ell1, c0)
+	r2, c2 = bits.Add64(r2, 0, c1)
+	r3, _ = bits.Add64(r3, m&(uint64(1)<<60), c2)
+
+	x[0], x[1], x[2], x[3] = r0, r1, r2, r3
+	x[4], x[5], x[6], x[7] = 0, 0, 0, 0
+}
@@ -2,6 +2,9 @@ package ed25519
 
 import (
 	"crypto/rand"
+	"encoding/binary"
+	"math/big"
+	"math/bits"
 	"testing"
 
 	"github.com/cloudflare/circl/internal/conv"
@@ -36,6 +39,49 @@ func TestCalculateS(t *testing.T) {
 	}
 }
 
+func TestCalculateSGeneric(t *testing.T) {
+	const testTimes = 1 << 12
+	var s0, s1, r, k, a [paramB]byte
+	for i := 0; i < testTimes; i++ {
+		_, _ = rand.Read(r[:])
+		_, _ = rand.Read(k[:])
+		_, _ = rand.Read(a[:])
+		if i == 0 {
+			// The largest inputs, giving the largest quotients.
+			for j := range r {
+				r[j], k[j], a[j] = 0xff, 0xff, 0xff
+			}
+		} else if i%3 == 0 {
+			// Scalars as used when signing, i.e., less than the order.
+			reduceModOrder(r[:], false)
+			reduceModOrder(k[:], false)
+			reduceModOrder(a[:], false)
+		}
+		calculateS(s0[:], r[:], k[:], a[:])
+		calculateSGeneric(s1[:], r[:], k[:], a[:])
+		if s0 != s1 {
+			test.ReportError(t, s0, s1, r, k, a)
+		}
+	}
+}
+
+func BenchmarkCalculateS(b *testing.B) {
+	var s, r, k, a [paramB]byte
+	_, _ = rand.Read(r[:])
+	_, _ = rand.Read(k[:])
+	_, _ = rand.Read(a[:])
+	b.Run("fast", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			calculateS(s[:], r[:], k[:], a[:])
+		}
+	})
+	b.Run("generic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			calculateSGeneric(s[:], r[:], k[:], a[:])
+		}
+	})
+}
+
 func TestReduction(t *testing.T) {
 	const testTimes = 1 << 10
 	var x, y [paramB * 2]byte
@@ -59,6 +105,51 @@ func TestReduction(t *testing.T) {
 	}
 }
 
+func TestReduceWide(t *testing.T) {
+	const testTimes = 1 << 10
+	var x [2 * paramB]byte
+	orderBig := conv.BytesLe2BigInt(order[:])
+	check := func(x []byte) {
+		got := ReduceWide(x)
+		bigX := conv.BytesLe2BigInt(x)
+		want := bigX.Mod(bigX, orderBig)
+		if conv.BytesLe2BigInt(got[:]).Cmp(want) != 0 {
+			test.ReportError(t, got, want, x)
+		}
+	}
+
+	for i := 0; i < testTimes; i++ {
+		_, _ = rand.Read(x[:])
+		check(x[:])
+		check(x[:i%len(x)])
+	}
+
+	// Values around multiples of the order, and the largest input.
+	one := big.NewInt(1)
+	max := new(big.Int).Lsh(one, 8*2*paramB)
+	for _, m := range []*big.Int{
+		big.NewInt(1),
+		big.NewInt(2),
+		new(big.Int).Lsh(one, 252),
+		new(big.Int).Div(max, orderBig),
+	} {
+		v := new(big.Int).Mul(m, orderBig)
+		for _, d := range []int64{-1, 0, 1} {
+			w := new(big.Int).Add(v, big.NewInt(d))
+			if w.Cmp(max) >= 0 {
+				continue
+			}
+			conv.BigInt2BytesLe(x[:], w)
+			check(x[:])
+		}
+	}
+	conv.BigInt2BytesLe(x[:], new(big.Int).Sub(max, one))
+	check(x[:])
+
+	err := test.CheckPanic(func() { ReduceWide(make([]byte, 2*paramB+1)) })
+	test.CheckNoErr(t, err, "ReduceWide should panic on long inputs")
+}
+
 func TestRangeOrder(t *testing.T) {
 	aboveOrder := [...][paramB]byte{
 		{ // order
@@ -89,3 +180,73 @@ func TestRangeOrder(t *testing.T) {
 		}
 	}
 }
+
+func TestReductionNearOrder(t *testing.T) {
+	var x [2 * paramB]byte
+	orderBig := conv.BytesLe2BigInt(order[:])
+	one := big.NewInt(1)
+	max := new(big.Int).Lsh(one, 8*2*paramB)
+	for _, m := range []*big.Int{
+		big.NewInt(1),
+		big.NewInt(2),
+		new(big.Int).Lsh(one, 252),
+		new(big.Int).Div(max, orderBig),
+	} {
+		v := new(big.Int).Mul(m, orderBig)
+		for _, d := range []int64{-1, 0, 1} {
+			w := new(big.Int).Add(v, big.NewInt(d))
+			if w.Cmp(max) >= 0 {
+				continue
+			}
+			conv.BigInt2BytesLe(x[:], w)
+			reduceModOrder(x[:], true)
+			got := conv.BytesLe2BigInt(x[:])
+			want := w.Mod(w, orderBig)
+			if got.Cmp(want) != 0 {
+				test.ReportError(t, got, want, m, d)
+			}
+		}
+	}
+}
+
+// calculateSGeneric performs s = r+k*a mod Order of the curve, as
+// calculateS did before using redMulAdd. It is the reference of the tests.
+func calculateSGeneric(s, r, k, a []byte) {
+	K := [4]uint64{
+		binary.LittleEndian.Uint64(k[0*8 : 1*8]),
+		binary.LittleEndian.Uint64(k[1*8 : 2*8]),
+		binary.LittleEndian.Uint64(k[2*8 : 3*8]),
+		binary.LittleEndian.Uint64(k[3*8 : 4*8]),
+	}
+	S := [8]uint64{
+		binary.LittleEndian.Uint64(r[0*8 : 1*8]),
+		binary.LittleEndian.Uint64(r[1*8 : 2*8]),
+		binary.LittleEndian.Uint64(r[2*8 : 3*8]),
+		binary.LittleEndian.Uint64(r[3*8 : 4*8]),
+	}
+	var c3 uint64
+	for i := range K {
+		ai := binary.LittleEndian.Uint64(a[i*8 : (i+1)*8])
+
+		h0, l0 := bits.Mul64(K[0], ai)
+		h1, l1 := bits.Mul64(K[1], ai)
+		h2, l2 := bits.Mul64(K[2], ai)
+		h3, l3 := bits.Mul64(K[3], ai)
+
+		l1, c0 := bits.Add64(h0, l1, 0)
+		l2, c1 := bits.Add64(h1, l2, c0)
+		l3, c2 := bits.Add64(h2, l3, c1)
+		l4, _ := bits.Add64(h3, 0, c2)
+
+		S[i+0], c0 = bits.Add64(S[i+0], l0, 0)
+		S[i+1], c1 = bits.Add64(S[i+1], l1, c0)
+		S[i+2], c2 = bits.Add64(S[i+2], l2, c1)
+		S[i+3], c3 = bits.Add64(S[i+3], l3, c2)
+		S[i+4], _ = bits.Add64(S[i+4], l4, c3)
+	}
+	red512(&S, true)
+	binary.LittleEndian.PutUint64(s[0*8:1*8], S[0])
+	binary.LittleEndian.PutUint64(s[1*8:2*8], S[1])
+	binary.LittleEndian.PutUint64(s[2*8:3*8], S[2])
+	binary.LittleEndian.PutUint64(s[3*8:4*8], S[3])
+}
@@ -5,8 +5,6 @@ import (
 	"encoding/binary"
 	"math/bits"
 
-	"github.com/cloudflare/circl/internal/conv"
-	"github.com/cloudflare/circl/math"
 	fp "github.com/cloudflare/circl/math/fp25519"
 )
 
@@ -137,26 +135,96 @@ func (P *pointR1) fixedMult(scalar []byte) {
 	}
 }
 
+// ctMult calculates P = [k]Q in constant time, where k is a scalar of
+// paramB bytes in little-endian order, using a fixed window of 4 bits.
+func (P *pointR1) ctMult(Q *pointR1, k []byte) {
+	if len(k) != paramB {
+		panic("wrong scalar size")
+	}
+	// T[i] = [i]Q
+	var T [16]pointR2
+	var R pointR1
+	var Q2, S pointR2
+	Q2.fromR1(Q)
+	R.SetIdentity()
+	T[0].fromR1(&R)
+	for i := 1; i < len(T); i++ {
+		R.add(&Q2)
+		T[i].fromR1(&R)
+	}
+
+	P.SetIdentity()
+	for i := 2*paramB - 1; i >= 0; i-- {
+		P.double()
+		P.double()
+		P.double()
+		P.double()
+		d := int32(k[i/2]>>(4*uint(i%2))) & 0xF
+		S = T[0]
+		for j := 1; j < len(T); j++ {
+			S.cmov(&T[j], subtle.ConstantTimeEq(int32(j), d))
+		}
+		P.add(&S)
+	}
+}
+
 const (
 	omegaFix = 7
 	omegaVar = 5
 )
 
+// wNAF obtains the window-w Non-Adjacent Form of the scalar k, which is
+// stored in little-endian order, such that k = sum( L[i]*2^i ). It returns
+// the number of digits up to the most significant non-zero digit.
+// Unlike math.OmegaNAF, it does not allocate memory.
+//
+// Non-constant time.
+func wNAF(L *[8*paramB + 1]int32, k []byte, w uint) (n int) {
+	var m [numWords64 + 1]uint64
+	for i := 0; i < numWords64; i++ {
+		m[i] = binary.LittleEndian.Uint64(k[8*i : 8*i+8])
+	}
+	mask := (uint64(1) << w) - 1
+	half := int64(1) << (w - 1)
+	for i := range L {
+		L[i] = 0
+		if m[0]&1 == 1 {
+			d := int64(m[0] & mask)
+			if d >= half {
+				d -= int64(1) << w
+			}
+			L[i] = int32(d)
+			n = i + 1
+
+			// m = m - d
+			s := uint64(d >> 63)
+			var c uint64
+			m[0], c = bits.Sub64(m[0], uint64(d), 0)
+			for j := 1; j < len(m); j++ {
+				m[j], c = bits.Sub64(m[j], s, c)
+			}
+		}
+		// m = m / 2
+		for j := 0; j < len(m)-1; j++ {
+			m[j] = (m[j] >> 1) | (m[j+1] << 63)
+		}
+		m[len(m)-1] >>= 1
+	}
+	return n
+}
+
 // doubleMult returns P=mG+nQ.
 func (P *pointR1) doubleMult(Q *pointR1, m, n []byte) {
-	nafFix := math.OmegaNAF(conv.BytesLe2BigInt(m), omegaFix)
-	nafVar := math.OmegaNAF(conv.BytesLe2BigInt(n), omegaVar)
-
-	if len(nafFix) > len(nafVar) {
-		nafVar = append(nafVar, make([]int32, len(nafFix)-len(nafVar))...)
-	} else if len(nafFix) < len(nafVar) {
-		nafFix = append(nafFix, make([]int32, len(nafVar)-len(nafFix))...)
+	var nafFix, nafVar [8*paramB + 1]int32
+	l := wNAF(&nafFix, m, omegaFix)
+	if lVar := wNAF(&nafVar, n, omegaVar); lVar > l {
+		l = lVar
 	}
 
 	var TabQ [1 << (omegaVar - 2)]pointR2
 	Q.oddMultiples(TabQ[:])
 	P.SetIdentity()
-	for i := len(nafFix) - 1; i >= 0; i-- {
+	for i := l - 1; i >= 0; i-- {
 		P.double()
 		// Generator point
 		if nafFix[i] != 0 {
@@ -178,3 +246,44 @@ func (P *pointR1) doubleMult(Q *pointR1, m, n []byte) {
 		}
 	}
 }
+
+// multiMult returns P = mG + sum n[i]Q[i], where the scalars are less than
+// 2^256. It overwrites Q.
+//
+// Non-constant time.
+func (P *pointR1) multiMult(m []byte, Q []pointR1, n [][paramB]byte) {
+	var nafFix [8*paramB + 1]int32
+	l := wNAF(&nafFix, m, omegaFix)
+	nafVar := make([][8*paramB + 1]int32, len(Q))
+	TabQ := make([][1 << (omegaVar - 2)]pointR2, len(Q))
+	for i := range Q {
+		if lVar := wNAF(&nafVar[i], n[i][:], omegaVar); lVar > l {
+			l = lVar
+		}
+		Q[i].oddMultiples(TabQ[i][:])
+	}
+
+	P.SetIdentity()
+	for i := l - 1; i >= 0; i-- {
+		P.double()
+		// Generator point
+		if nafFix[i] != 0 {
+			idxM := absolute(nafFix[i]) >> 1
+			R := tabVerif[idxM]
+			if nafFix[i] < 0 {
+				R.neg()
+			}
+			P.mixAdd(&R)
+		}
+		// Variable input points
+		for j := range TabQ {
+			if d := nafVar[j][i]; d != 0 {
+				S := TabQ[j][absolute(d)>>1]
+				if d < 0 {
+					S.neg()
+				}
+				P.add(&S)
+			}
+		}
+	}
+}
@@ -1,6 +1,12 @@
 package ed25519
 
-import fp "github.com/cloudflare/circl/math/fp25519"
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"math/bits"
+
+	fp "github.com/cloudflare/circl/math/fp25519"
+)
 
 type (
 	pointR1 struct{ x, y, z, ta, tb fp.Elt }
@@ -86,6 +92,46 @@ func (P *pointR1) FromBytes(k []byte) bool {
 	return true
 }
 
+// fromBytesCT is as FromBytes, but it runs in constant time regardless of
+// the value of k. It returns 1 if k is a valid encoding, and 0 otherwise;
+// in the latter case, P holds an unspecified value.
+func (P *pointR1) fromBytesCT(k []byte) int {
+	if len(k) != paramB {
+		panic("wrong size")
+	}
+	signX := int(k[paramB-1] >> 7)
+	copy(P.y[:], k[:fp.Size])
+	P.y[fp.Size-1] &= 0x7F
+
+	// isCanonical = 1 if y < p, computed from the borrow of y-p.
+	p := fp.P()
+	var borrow uint64
+	for i := 0; i < fp.Size; i += 8 {
+		_, borrow = bits.Sub64(
+			binary.LittleEndian.Uint64(P.y[i:]),
+			binary.LittleEndian.Uint64(p[i:]), borrow)
+	}
+	isCanonical := int(borrow)
+
+	one, u, v := &fp.Elt{}, &fp.Elt{}, &fp.Elt{}
+	fp.SetOne(one)
+	fp.Sqr(u, &P.y)                  // u = y^2
+	fp.Mul(v, u, &paramD)            // v = dy^2
+	fp.Sub(u, u, one)                // u = y^2-1
+	fp.Add(v, v, one)                // v = dy^2+1
+	isQR := fp.InvSqrtCT(&P.x, u, v) // x = sqrt(u/v)
+	fp.Modp(&P.x)                    // x = x mod p
+	isZero := subtle.ConstantTimeCompare(P.x[:], make([]byte, fp.Size))
+
+	negX := &fp.Elt{}
+	fp.Neg(negX, &P.x)
+	fp.Cmov(&P.x, negX, uint(signX^int(P.x[0]&1)))
+	P.ta = P.x
+	P.tb = P.y
+	fp.SetOne(&P.z)
+	return isCanonical & isQR &^ (isZero & signX)
+}
+
 // double calculates 2P for curves with A=-1.
 func (P *pointR1) double() {
 	Px, Py, Pz, Pta, Ptb := &P.x, &P.y, &P.z, &P.ta, &P.tb
@@ -193,3 +239,108 @@ func (P *pointR3) cmov(Q *pointR3, b int) {
 	fp.Cmov(&P.subYX, &Q.subYX, uint(b))
 	fp.Cmov(&P.dt2, &Q.dt2, uint(b))
 }
+
+func (P *pointR2) cmov(Q *pointR2, b int) {
+	P.pointR3.cmov(&Q.pointR3, b)
+	fp.Cmov(&P.z2, &Q.z2, uint(b))
+}
+
+// PointIsValid returns true if enc is the encoding of a point on the
+// Ed25519 curve as defined in RFC-8032, and false otherwise.
+func PointIsValid(enc []byte) bool {
+	var P pointR1
+	return len(enc) == paramB && P.FromBytes(enc)
+}
+
+// PointInPrimeSubgroup returns true if enc is the encoding of a point on
+// the Ed25519 curve that belongs to the subgroup of prime order, i.e.,
+// [order]P is the identity. It returns false for invalid encodings and for
+// points having a component in the torsion subgroup.
+// This function is not constant time.
+func PointInPrimeSubgroup(enc []byte) bool {
+	var P, Q, identity pointR1
+	if len(enc) != paramB || !P.FromBytes(enc) {
+		return false
+	}
+	Q.doubleMult(&P, make([]byte, paramB), order[:])
+	identity.SetIdentity()
+	return Q.isEqual(&identity)
+}
+
+// PublicKeyCoords returns the affine coordinates of the point encoded by
+// public, each one as 32 bytes in little-endian order. It returns ok=false
+// if public is not a valid encoding of a point.
+func PublicKeyCoords(public PublicKey) (x, y []byte, ok bool) {
+	var P pointR1
+	if len(public) != PublicKeySize || !P.FromBytes(public) {
+		return nil, nil, false
+	}
+	x, y = make([]byte, fp.Size), make([]byte, fp.Size)
+	_ = fp.ToBytes(x, &P.x)
+	_ = fp.ToBytes(y, &P.y)
+	return x, y, true
+}
+
+// FromBytesCT is as PublicKeyCoords, but decoding runs in constant time
+// regardless of the value of public; only its length may leak. It returns
+// ok=1 if public is a valid encoding of a point, and ok=0 otherwise, in
+// which case x and y hold unspecified values.
+func FromBytesCT(public PublicKey) (x, y []byte, ok int) {
+	if len(public) != PublicKeySize {
+		return nil, nil, 0
+	}
+	var P pointR1
+	ok = P.fromBytesCT(public)
+	x, y = make([]byte, fp.Size), make([]byte, fp.Size)
+	_ = fp.ToBytes(x, &P.x)
+	_ = fp.ToBytes(y, &P.y)
+	return x, y, ok
+}
+
+// NormalizePublicKey returns the canonical encoding of the point encoded by
+// public, which may have a y-coordinate not reduced modulo p, or the sign
+// bit set for x = 0. All the encodings of a point normalize to the same
+// bytes. It returns false if public does not decode to a point, even
+// allowing non-canonical encodings.
+func NormalizePublicKey(public PublicKey) (PublicKey, bool) {
+	var P pointR1
+	if len(public) != PublicKeySize || !P.fromBytesOpt(public, true) {
+		return nil, false
+	}
+	out := make(PublicKey, PublicKeySize)
+	_ = P.ToBytes(out)
+	return out, true
+}
+
+// PublicKeyFromCoords returns the public key encoding the point with the
+// affine coordinates x and y, each one given as 32 bytes in little-endian
+// order. It returns ok=false if the coordinates are not in the range [0,p),
+// or if the point is not on the curve.
+func PublicKeyFromCoords(x, y []byte) (PublicKey, bool) {
+	p := fp.P()
+	if len(x) != fp.Size || len(y) != fp.Size ||
+		!isLessThan(x, p[:]) || !isLessThan(y, p[:]) {
+		return nil, false
+	}
+
+	// Check that -x^2 + y^2 = 1 + dx^2y^2.
+	var X, Y, xx, yy, l, r, one fp.Elt
+	copy(X[:], x)
+	copy(Y[:], y)
+	fp.Sqr(&xx, &X)
+	fp.Sqr(&yy, &Y)
+	fp.Sub(&l, &yy, &xx)
+	fp.Mul(&r, &xx, &yy)
+	fp.Mul(&r, &r, &paramD)
+	fp.SetOne(&one)
+	fp.Add(&r, &r, &one)
+	fp.Sub(&l, &l, &r)
+	if !fp.IsZero(&l) {
+		return nil, false
+	}
+
+	public := make(PublicKey, PublicKeySize)
+	copy(public, y)
+	public[paramB-1] |= (x[0] & 1) << 7
+	return public, true
+}
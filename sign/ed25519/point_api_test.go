@@ -0,0 +1,163 @@
+package ed25519_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed25519"
+)
+
+func TestPointValidity(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	test.CheckNoErr(t, err, "GenerateKey failed")
+
+	for _, c := range []struct {
+		name            string
+		enc             string
+		valid, subgroup bool
+	}{
+		{"base", "5866666666666666666666666666666666666666666666666666666666666666", true, true},
+		{"identity", "0100000000000000000000000000000000000000000000000000000000000000", true, true},
+		{"publicKey", hex.EncodeToString(pub), true, true},
+		{"order2", "ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f", true, false},
+		{"order4", "0000000000000000000000000000000000000000000000000000000000000000", true, false},
+		{"order8", "26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05", true, false},
+		{"yEqualP", "edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f", false, false},
+		{"notOnCurve", "9a0abec623cb5a234e49d892c272d5a827ff42077de3f2b474759d0434eda670", false, false},
+		{"short", "58666666", false, false},
+	} {
+		enc, err := hex.DecodeString(c.enc)
+		test.CheckNoErr(t, err, "bad hex")
+
+		got := ed25519.PointIsValid(enc)
+		want := c.valid
+		if got != want {
+			test.ReportError(t, got, want, c.name)
+		}
+
+		got = ed25519.PointInPrimeSubgroup(enc)
+		want = c.subgroup
+		if got != want {
+			test.ReportError(t, got, want, c.name)
+		}
+	}
+}
+
+func TestPublicKeyCoords(t *testing.T) {
+	const testTimes = 1 << 7
+	keys := []string{
+		"5866666666666666666666666666666666666666666666666666666666666666",
+		"0100000000000000000000000000000000000000000000000000000000000000",
+		"ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05",
+	}
+	for i := 0; i < testTimes; i++ {
+		pub, _, _ := ed25519.GenerateKey(rand.Reader)
+		keys = append(keys, hex.EncodeToString(pub))
+	}
+
+	for _, k := range keys {
+		enc, _ := hex.DecodeString(k)
+		x, y, ok := ed25519.PublicKeyCoords(enc)
+		test.CheckOk(ok, "PublicKeyCoords failed", t)
+
+		got, ok := ed25519.PublicKeyFromCoords(x, y)
+		test.CheckOk(ok, "PublicKeyFromCoords failed", t)
+		want := ed25519.PublicKey(enc)
+		if !got.Equal(want) {
+			test.ReportError(t, got, want, x, y)
+		}
+
+		// Changing y, the point is no longer on the curve.
+		y[0] ^= 1
+		_, ok = ed25519.PublicKeyFromCoords(x, y)
+		test.CheckOk(!ok, "point must not be on the curve", t)
+	}
+
+	p, _ := hex.DecodeString("edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f")
+	one, _ := hex.DecodeString("0100000000000000000000000000000000000000000000000000000000000000")
+	zero := make([]byte, 32)
+	for _, c := range [][2][]byte{{p, one}, {zero, p}, {zero, one[:31]}} {
+		_, ok := ed25519.PublicKeyFromCoords(c[0], c[1])
+		test.CheckOk(!ok, "non-canonical coordinates must be rejected", t)
+	}
+	_, _, ok := ed25519.PublicKeyCoords(p)
+	test.CheckOk(!ok, "invalid encoding must be rejected", t)
+	_, _, ok = ed25519.PublicKeyCoords(one[:31])
+	test.CheckOk(!ok, "short encoding must be rejected", t)
+}
+
+func TestFromBytesCT(t *testing.T) {
+	const testTimes = 1 << 10
+	encs := [][]byte{}
+	for _, k := range []string{
+		"5866666666666666666666666666666666666666666666666666666666666666",
+		"0100000000000000000000000000000000000000000000000000000000000000",
+		"0100000000000000000000000000000000000000000000000000000000000080", // x=0 with sign.
+		"ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		"edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f", // y=p.
+		"eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", // y=p+1.
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000000000000000000000000080",
+		"26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05",
+		"9a0abec623cb5a234e49d892c272d5a827ff42077de3f2b474759d0434eda670",
+	} {
+		enc, _ := hex.DecodeString(k)
+		encs = append(encs, enc)
+	}
+	for i := 0; i < testTimes; i++ {
+		enc := make([]byte, ed25519.PublicKeySize)
+		_, _ = rand.Read(enc)
+		encs = append(encs, enc)
+	}
+
+	for _, enc := range encs {
+		gotX, gotY, gotOk := ed25519.FromBytesCT(enc)
+		wantX, wantY, wantOk := ed25519.PublicKeyCoords(enc)
+		if (gotOk == 1) != wantOk {
+			test.ReportError(t, gotOk, wantOk, enc)
+		}
+		if wantOk && (!bytes.Equal(gotX, wantX) || !bytes.Equal(gotY, wantY)) {
+			test.ReportError(t, [][]byte{gotX, gotY}, [][]byte{wantX, wantY}, enc)
+		}
+	}
+
+	_, _, ok := ed25519.FromBytesCT(encs[0][:31])
+	test.CheckOk(ok == 0, "short encoding must be rejected", t)
+}
+
+func TestNormalizePublicKey(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	test.CheckNoErr(t, err, "GenerateKey failed")
+
+	for _, c := range []struct{ name, enc, want string }{
+		{"publicKey", hex.EncodeToString(pub), hex.EncodeToString(pub)},
+		{"identity", "0100000000000000000000000000000000000000000000000000000000000000", "0100000000000000000000000000000000000000000000000000000000000000"},
+		{"yEqualPPlus1", "eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f", "0100000000000000000000000000000000000000000000000000000000000000"},
+		{"xZeroSignSet", "0100000000000000000000000000000000000000000000000000000000000080", "0100000000000000000000000000000000000000000000000000000000000000"},
+		{"yEqualPPlus1SignSet", "eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", "0100000000000000000000000000000000000000000000000000000000000000"},
+		{"yEqualP", "edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f", "0000000000000000000000000000000000000000000000000000000000000000"},
+		{"yEqualPSignSet", "edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", "0000000000000000000000000000000000000000000000000000000000000080"},
+	} {
+		enc, _ := hex.DecodeString(c.enc)
+		want, _ := hex.DecodeString(c.want)
+		got, ok := ed25519.NormalizePublicKey(enc)
+		if !ok || !bytes.Equal(got, want) {
+			test.ReportError(t, got, want, c.name, ok)
+		}
+		test.CheckOk(ed25519.PointIsValid(got), "normalized key must be canonical", t)
+	}
+
+	for _, enc := range []string{
+		"9a0abec623cb5a234e49d892c272d5a827ff42077de3f2b474759d0434eda670",
+		"58666666",
+	} {
+		b, _ := hex.DecodeString(enc)
+		_, ok := ed25519.NormalizePublicKey(b)
+		test.CheckOk(!ok, "invalid encoding must be rejected", t)
+	}
+}
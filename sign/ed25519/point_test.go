@@ -3,9 +3,12 @@ package ed25519
 import (
 	"crypto/rand"
 	"flag"
+	"reflect"
 	"testing"
 
+	"github.com/cloudflare/circl/internal/conv"
 	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/math"
 )
 
 func randomPoint(P *pointR1) {
@@ -63,6 +66,26 @@ func TestPoint(t *testing.T) {
 	})
 }
 
+func TestWNAF(t *testing.T) {
+	const testTimes = 1 << 10
+	var L [8*paramB + 1]int32
+	k := make([]byte, paramB)
+	for i := 0; i < testTimes; i++ {
+		_, _ = rand.Read(k)
+		for _, w := range []uint{omegaFix, omegaVar} {
+			n := wNAF(&L, k, w)
+			got := L[:n]
+			want := math.OmegaNAF(conv.BytesLe2BigInt(k), w)
+			for len(want) > 0 && want[len(want)-1] == 0 {
+				want = want[:len(want)-1]
+			}
+			if !reflect.DeepEqual(got, want) {
+				test.ReportError(t, got, want, k, w)
+			}
+		}
+	}
+}
+
 var runLongBench = flag.Bool("long", false, "runs longer benchmark")
 
 func BenchmarkPoint(b *testing.B) {
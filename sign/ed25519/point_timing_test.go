@@ -0,0 +1,53 @@
+//go:build timing
+// +build timing
+
+package ed25519_test
+
+import (
+	"crypto/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/circl/sign/ed25519"
+)
+
+// TestFromBytesCTTiming compares the running time of FromBytesCT on valid
+// and invalid encodings. It is sensitive to noise, so it only runs with the
+// timing build tag: go test -tags timing -run Timing.
+func TestFromBytesCTTiming(t *testing.T) {
+	const testTimes = 1 << 10
+	const maxRelDiff = 0.05
+
+	inputs := [2][]ed25519.PublicKey{}
+	for len(inputs[0]) < testTimes || len(inputs[1]) < testTimes {
+		enc := make([]byte, ed25519.PublicKeySize)
+		_, _ = rand.Read(enc)
+		c := 1
+		if ed25519.PointIsValid(enc) {
+			c = 0
+		}
+		if len(inputs[c]) < testTimes {
+			inputs[c] = append(inputs[c], enc)
+		}
+	}
+
+	var times [2][]time.Duration
+	for i := 0; i < testTimes; i++ {
+		// Alternate the classes to spread the noise evenly.
+		for c := range inputs {
+			start := time.Now()
+			ed25519.FromBytesCT(inputs[c][i])
+			times[c] = append(times[c], time.Since(start))
+		}
+	}
+
+	median := func(d []time.Duration) float64 {
+		sort.Slice(d, func(i, j int) bool { return d[i] < d[j] })
+		return float64(d[len(d)/2])
+	}
+	valid, invalid := median(times[0]), median(times[1])
+	if diff := (valid - invalid) / valid; diff > maxRelDiff || diff < -maxRelDiff {
+		t.Errorf("median times differ: valid %v ns, invalid %v ns", valid, invalid)
+	}
+}
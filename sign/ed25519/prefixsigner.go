@@ -0,0 +1,58 @@
+package ed25519
+
+import (
+	"crypto/sha512"
+	"encoding"
+	"hash"
+	"strconv"
+)
+
+// PrefixSigner creates Ed25519 signatures of messages that share a fixed
+// prefix, given only the rest of each message. The SHA-512 state of the
+// nonce derivation after absorbing the prefix is computed once, and it is
+// restored on each signature instead of hashing the prefix again.
+//
+// The hash of the second pass, SHA-512(R || A || M), cannot be precomputed
+// in the same way, because R comes before the message and changes with
+// every signature, so the prefix is still hashed once per signature.
+//
+// A PrefixSigner holds secret key material. It is not safe for concurrent
+// use by multiple goroutines.
+type PrefixSigner struct {
+	key    expandedKey
+	prefix []byte
+	state  []byte
+	h      hash.Hash // scratch
+}
+
+// NewPrefixSigner returns a PrefixSigner for messages starting with prefix.
+// It will panic if len(privateKey) is not PrivateKeySize.
+func NewPrefixSigner(privateKey PrivateKey, prefix []byte) *PrefixSigner {
+	if l := len(privateKey); l != PrivateKeySize {
+		panic("ed25519: bad private key length: " + strconv.Itoa(l))
+	}
+	s := &PrefixSigner{h: sha512.New(), prefix: append([]byte{}, prefix...)}
+	s.key.expand(s.h, privateKey)
+	s.h.Reset()
+	_, _ = s.h.Write(s.key.prefix[:])
+	_, _ = s.h.Write(s.prefix)
+	state, err := s.h.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	s.state = state
+	return s
+}
+
+// Sign returns the signature of prefix || suffix, which is the same as
+// the one given by Sign.
+func (s *PrefixSigner) Sign(suffix []byte) []byte {
+	if err := s.h.(encoding.BinaryUnmarshaler).UnmarshalBinary(s.state); err != nil {
+		panic(err)
+	}
+	_, _ = s.h.Write(suffix)
+	s.h.Sum(s.key.r[:0])
+	signature := make([]byte, SignatureSize)
+	s.key.finish(s.h, signature, s.prefix, suffix, nil, false)
+	return signature
+}
@@ -0,0 +1,51 @@
+package ed25519_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed25519"
+)
+
+func TestPrefixSigner(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	test.CheckNoErr(t, err, "GenerateKey failed")
+
+	for _, n := range []int{0, 1, 127, 128, 1000} {
+		prefix := make([]byte, n)
+		_, _ = rand.Read(prefix)
+		s := ed25519.NewPrefixSigner(priv, prefix)
+		for _, suffix := range [][]byte{nil, []byte("a"), bytes.Repeat([]byte("b"), 200)} {
+			msg := append(append([]byte{}, prefix...), suffix...)
+			got := s.Sign(suffix)
+			want := ed25519.Sign(priv, msg)
+			if !bytes.Equal(got, want) {
+				test.ReportError(t, got, want, n, suffix)
+			}
+		}
+	}
+
+	err = test.CheckPanic(func() { ed25519.NewPrefixSigner(priv[:10], nil) })
+	test.CheckNoErr(t, err, "NewPrefixSigner must panic on bad key length")
+}
+
+func BenchmarkPrefixSigner(b *testing.B) {
+	_, priv, _ := ed25519.GenerateKey(rand.Reader)
+	prefix := make([]byte, 4096)
+	suffix := []byte("Hello, world!")
+	msg := append(append([]byte{}, prefix...), suffix...)
+	s := ed25519.NewPrefixSigner(priv, prefix)
+	b.Run(fmt.Sprintf("Sign/%v", len(prefix)), func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ed25519.Sign(priv, msg)
+		}
+	})
+	b.Run(fmt.Sprintf("PrefixSigner/%v", len(prefix)), func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			s.Sign(suffix)
+		}
+	})
+}
@@ -0,0 +1,136 @@
+package ed25519
+
+import (
+	"errors"
+	"io"
+)
+
+// ScalarSize is the size, in bytes, of the canonical encoding of a Scalar.
+const ScalarSize = paramB
+
+var (
+	errScalarSize      = errors.New("ed25519: bad scalar length")
+	errScalarEncoding  = errors.New("ed25519: non-canonical scalar encoding")
+	errUniformBytesLen = errors.New("ed25519: bad uniform bytes length")
+)
+
+// Scalar represents an integer modulo the order of the prime-order subgroup
+// of the Ed25519 curve, i.e., L = 2^252+27742317777372353535851937790883648493.
+// The zero value is a valid scalar equal to zero.
+type Scalar struct {
+	// s is the minimal residue of the scalar in little-endian order.
+	s [paramB]byte
+}
+
+var (
+	scalarOne         = [paramB]byte{1}
+	scalarOrderMinus1 = [paramB]byte{
+		0xec, 0xd3, 0xf5, 0x5c, 0x1a, 0x63, 0x12, 0x58,
+		0xd6, 0x9c, 0xf7, 0xa2, 0xde, 0xf9, 0xde, 0x14,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+	}
+	scalarOrderMinus2 = [paramB]byte{
+		0xeb, 0xd3, 0xf5, 0x5c, 0x1a, 0x63, 0x12, 0x58,
+		0xd6, 0x9c, 0xf7, 0xa2, 0xde, 0xf9, 0xde, 0x14,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+	}
+)
+
+// SetBytes sets z to the scalar encoded by b, which must be the ScalarSize
+// bytes of a number less than the order in little-endian order. Otherwise,
+// it returns an error and z is unmodified.
+func (z *Scalar) SetBytes(b []byte) error {
+	if len(b) != ScalarSize {
+		return errScalarSize
+	}
+	if !isLessThanOrder(b) {
+		return errScalarEncoding
+	}
+	copy(z.s[:], b)
+	return nil
+}
+
+// SetUniformBytes sets z to the 64-byte little-endian number stored in b
+// reduced modulo the order. If b is uniformly random, so is z.
+func (z *Scalar) SetUniformBytes(b []byte) error {
+	if len(b) != 2*paramB {
+		return errUniformBytesLen
+	}
+	z.s = ReduceWide(b)
+	return nil
+}
+
+// RandomScalar returns the canonical encoding of a uniformly random scalar,
+// i.e., ScalarSize bytes in little-endian order. It reads 64 bytes from rand
+// and reduces them modulo the order, so the bias of the result is at most
+// 2^-259.
+func RandomScalar(rand io.Reader) ([]byte, error) {
+	var b [2 * paramB]byte
+	if _, err := io.ReadFull(rand, b[:]); err != nil {
+		return nil, err
+	}
+	var z Scalar
+	_ = z.SetUniformBytes(b[:])
+	return z.Bytes(), nil
+}
+
+// RandomNonzeroScalar is as RandomScalar, except that the scalar returned is
+// never zero.
+func RandomNonzeroScalar(rand io.Reader) ([]byte, error) {
+	for {
+		k, err := RandomScalar(rand)
+		if err != nil {
+			return nil, err
+		}
+		var z Scalar
+		_ = z.SetBytes(k)
+		if !z.IsZero() {
+			return k, nil
+		}
+	}
+}
+
+// Bytes returns the canonical encoding of z, i.e., the ScalarSize bytes of
+// its minimal residue in little-endian order.
+func (z *Scalar) Bytes() []byte { b := z.s; return b[:] }
+
+// IsZero returns true if z is equal to zero.
+func (z *Scalar) IsZero() bool {
+	var v byte
+	for i := range z.s {
+		v |= z.s[i]
+	}
+	return v == 0
+}
+
+// Add calculates z = x + y mod order.
+func (z *Scalar) Add(x, y *Scalar) { calculateS(z.s[:], x.s[:], y.s[:], scalarOne[:]) }
+
+// Sub calculates z = x - y mod order.
+func (z *Scalar) Sub(x, y *Scalar) { calculateS(z.s[:], x.s[:], y.s[:], scalarOrderMinus1[:]) }
+
+// Mul calculates z = x * y mod order.
+func (z *Scalar) Mul(x, y *Scalar) { var zero Scalar; calculateS(z.s[:], zero.s[:], x.s[:], y.s[:]) }
+
+// Neg calculates z = -x mod order.
+func (z *Scalar) Neg(x *Scalar) {
+	var zero Scalar
+	calculateS(z.s[:], zero.s[:], x.s[:], scalarOrderMinus1[:])
+}
+
+// Inv calculates z = 1/x mod order using Fermat's little theorem, or z = 0
+// if x is zero. It runs in constant time.
+func (z *Scalar) Inv(x *Scalar) {
+	var y Scalar
+	xx := *x
+	y.s = scalarOne
+	for i := 8*paramB - 1; i >= 0; i-- {
+		y.Mul(&y, &y)
+		if (scalarOrderMinus2[i/8]>>uint(i%8))&1 == 1 {
+			y.Mul(&y, &xx)
+		}
+	}
+	*z = y
+}
@@ -0,0 +1,185 @@
+package ed25519
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/conv"
+	"github.com/cloudflare/circl/internal/test"
+)
+
+func randomScalar(t testing.TB) (*Scalar, *big.Int) {
+	var b [2 * paramB]byte
+	var s Scalar
+	_, _ = rand.Read(b[:])
+	err := s.SetUniformBytes(b[:])
+	test.CheckNoErr(t, err, "SetUniformBytes failed")
+	return &s, conv.BytesLe2BigInt(s.Bytes())
+}
+
+func TestScalar(t *testing.T) {
+	const testTimes = 1 << 9
+	orderBig := conv.BytesLe2BigInt(order[:])
+
+	t.Run("arith", func(t *testing.T) {
+		var z Scalar
+		for i := 0; i < testTimes; i++ {
+			x, bigX := randomScalar(t)
+			y, bigY := randomScalar(t)
+			for _, c := range []struct {
+				name string
+				f    func()
+				want *big.Int
+			}{
+				{"add", func() { z.Add(x, y) }, new(big.Int).Add(bigX, bigY)},
+				{"sub", func() { z.Sub(x, y) }, new(big.Int).Sub(bigX, bigY)},
+				{"mul", func() { z.Mul(x, y) }, new(big.Int).Mul(bigX, bigY)},
+				{"neg", func() { z.Neg(x) }, new(big.Int).Neg(bigX)},
+				{"inv", func() { z.Inv(x) }, new(big.Int).ModInverse(bigX, orderBig)},
+			} {
+				c.f()
+				got := conv.BytesLe2BigInt(z.Bytes())
+				want := c.want.Mod(c.want, orderBig)
+				if got.Cmp(want) != 0 {
+					test.ReportError(t, got, want, c.name, bigX, bigY)
+				}
+			}
+		}
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		var z, zero Scalar
+		test.CheckOk(zero.IsZero(), "zero value must be zero", t)
+		x, _ := randomScalar(t)
+		z.Sub(x, x)
+		test.CheckOk(z.IsZero(), "x-x must be zero", t)
+		z.Inv(&zero)
+		test.CheckOk(z.IsZero(), "inverse of zero must be zero", t)
+	})
+
+	t.Run("serialization", func(t *testing.T) {
+		var z Scalar
+		for i := 0; i < testTimes; i++ {
+			x, _ := randomScalar(t)
+			err := z.SetBytes(x.Bytes())
+			test.CheckNoErr(t, err, "SetBytes failed")
+			if z != *x {
+				test.ReportError(t, z, x)
+			}
+		}
+
+		// Non-canonical encodings: the order, and 2^256-1.
+		var all [paramB]byte
+		for i := range all {
+			all[i] = 0xff
+		}
+		for _, b := range [][]byte{order[:], all[:]} {
+			z := Scalar{s: [paramB]byte{7}}
+			want := z
+			err := z.SetBytes(b)
+			test.CheckIsErr(t, err, "SetBytes should fail")
+			if z != want {
+				test.ReportError(t, z, want)
+			}
+		}
+		test.CheckIsErr(t, z.SetBytes(make([]byte, paramB+1)), "SetBytes should fail")
+		test.CheckIsErr(t, z.SetUniformBytes(make([]byte, paramB)), "SetUniformBytes should fail")
+	})
+}
+
+func TestRandomScalar(t *testing.T) {
+	t.Run("uniform", func(t *testing.T) {
+		// Chi-square test on the top four bits of the scalars. Since the
+		// order is slightly above 2^252, the 16 buckets are equally likely.
+		const (
+			numBuckets = 16
+			numSamples = 1 << 14
+			// 99.9th percentile of the chi-square distribution with 15
+			// degrees of freedom.
+			threshold = 37.70
+		)
+		var counts [numBuckets]int
+		for i := 0; i < numSamples; i++ {
+			k, err := RandomScalar(rand.Reader)
+			test.CheckNoErr(t, err, "RandomScalar failed")
+			if len(k) != ScalarSize || !isLessThanOrder(k) {
+				t.Fatalf("non-canonical scalar: %x", k)
+			}
+			counts[k[paramB-1]&0xF]++
+		}
+		expected := float64(numSamples) / numBuckets
+		chi2 := 0.0
+		for _, c := range counts {
+			d := float64(c) - expected
+			chi2 += d * d / expected
+		}
+		if chi2 > threshold {
+			t.Errorf("chi-square statistic %v exceeds %v: %v", chi2, threshold, counts)
+		}
+	})
+
+	t.Run("nonzero", func(t *testing.T) {
+		// The order itself reduces to zero, so it must be skipped.
+		var ell [2 * paramB]byte
+		copy(ell[:], order[:])
+		r := io.MultiReader(bytes.NewReader(ell[:]), bytes.NewReader(make([]byte, 2*paramB)), rand.Reader)
+
+		k, err := RandomScalar(bytes.NewReader(ell[:]))
+		test.CheckNoErr(t, err, "RandomScalar failed")
+		var z Scalar
+		test.CheckNoErr(t, z.SetBytes(k), "SetBytes failed")
+		test.CheckOk(z.IsZero(), "order must reduce to zero", t)
+
+		k, err = RandomNonzeroScalar(r)
+		test.CheckNoErr(t, err, "RandomNonzeroScalar failed")
+		test.CheckNoErr(t, z.SetBytes(k), "SetBytes failed")
+		test.CheckOk(!z.IsZero(), "RandomNonzeroScalar returned zero", t)
+	})
+
+	t.Run("short", func(t *testing.T) {
+		_, err := RandomScalar(bytes.NewReader(make([]byte, paramB)))
+		test.CheckIsErr(t, err, "RandomScalar should fail")
+		_, err = RandomNonzeroScalar(bytes.NewReader(nil))
+		test.CheckIsErr(t, err, "RandomNonzeroScalar should fail")
+	})
+}
+
+func BenchmarkScalar(b *testing.B) {
+	x, _ := randomScalar(b)
+	y, _ := randomScalar(b)
+	var z Scalar
+	b.Run("Mul", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z.Mul(x, y)
+		}
+	})
+	b.Run("Inv", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z.Inv(x)
+		}
+	})
+}
+
+func TestPrivateKeyScalar(t *testing.T) {
+	const testTimes = 1 << 6
+	for i := 0; i < testTimes; i++ {
+		_, priv, err := GenerateKey(rand.Reader)
+		test.CheckNoErr(t, err, "GenerateKey failed")
+		a := priv.Scalar()
+		if len(a) != ScalarSize || !isLessThanOrder(a) {
+			t.Fatalf("non-canonical scalar: %x", a)
+		}
+
+		var P pointR1
+		got := make([]byte, PublicKeySize)
+		P.fixedMult(a)
+		_ = P.ToBytes(got)
+		want := priv.Public().(PublicKey)
+		if !bytes.Equal(got, want) {
+			test.ReportError(t, got, want, priv)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package ed25519
+
+import (
+	"crypto/sha512"
+	"fmt"
+)
+
+// SignBatch signs each of the messages with privateKey as Sign does, and
+// writes the signatures contiguously into out, which must be
+// SignatureSize*len(messages) bytes long; the i-th signature is
+// out[i*SignatureSize:(i+1)*SignatureSize]. The private key is expanded only
+// once, and the SHA-512 state is shared by all the signatures, so it does
+// not allocate memory per message. It returns an error if the length of
+// privateKey or out is wrong, in which case out is unmodified.
+func SignBatch(privateKey PrivateKey, messages [][]byte, out []byte) error {
+	if l := len(privateKey); l != PrivateKeySize {
+		return fmt.Errorf("ed25519: bad private key length: %v", l)
+	}
+	if l, want := len(out), SignatureSize*len(messages); l != want {
+		return fmt.Errorf("ed25519: bad output length: %v, want %v", l, want)
+	}
+
+	H := sha512.New()
+	var key expandedKey
+	key.expand(H, privateKey)
+	for i, msg := range messages {
+		key.sign(H, out[i*SignatureSize:(i+1)*SignatureSize], msg, nil, false)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package ed25519_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed25519"
+)
+
+func TestSignBatch(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	test.CheckNoErr(t, err, "failed to generate key")
+
+	for _, n := range []int{0, 1, 7} {
+		messages := make([][]byte, n)
+		for i := range messages {
+			messages[i] = []byte(fmt.Sprintf("message %v", i))
+		}
+		out := make([]byte, n*ed25519.SignatureSize)
+		err = ed25519.SignBatch(priv, messages, out)
+		test.CheckNoErr(t, err, "SignBatch failed")
+
+		for i, msg := range messages {
+			sig := out[i*ed25519.SignatureSize : (i+1)*ed25519.SignatureSize]
+			if !ed25519.Verify(pub, msg, sig) {
+				test.ReportError(t, false, true, i, msg, sig)
+			}
+			if want := ed25519.Sign(priv, msg); !bytes.Equal(sig, want) {
+				test.ReportError(t, sig, want, i, msg)
+			}
+		}
+
+		err = ed25519.SignBatch(priv, messages, make([]byte, len(out)+1))
+		test.CheckIsErr(t, err, "SignBatch should fail on bad output length")
+	}
+
+	err = ed25519.SignBatch(priv[:ed25519.SeedSize], nil, nil)
+	test.CheckIsErr(t, err, "SignBatch should fail on bad private key length")
+}
+
+func BenchmarkSignBatch(b *testing.B) {
+	const n = 64
+	_, priv, _ := ed25519.GenerateKey(rand.Reader)
+	messages := make([][]byte, n)
+	for i := range messages {
+		messages[i] = []byte("Hello, world!")
+	}
+	out := make([]byte, n*ed25519.SignatureSize)
+
+	b.Run("Sign", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, msg := range messages {
+				ed25519.Sign(priv, msg)
+			}
+		}
+	})
+	b.Run("SignBatch", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = ed25519.SignBatch(priv, messages, out)
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package ed25519
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/binary"
+)
+
+// signTreeDomain separates the hashes of SignTree from those of MerkleRoot.
+const signTreeDomain = "Ed25519 signature tree"
+
+// signTreeLabel is the prefix of the labels passed to NewKeyFromRoot to
+// derive the key of each leaf, which is followed by the 64-bit big-endian
+// index of the leaf.
+const signTreeLabel = "Ed25519 signature tree leaf "
+
+// TreeSignatureSize is the length in bytes of the leaf signatures returned
+// by SignTree, which consist of the public key of the leaf followed by an
+// Ed25519 signature.
+const TreeSignatureSize = PublicKeySize + SignatureSize
+
+// SignTree derives one key per leaf from seed, signs each leaf with its key,
+// and builds a Merkle tree over the public keys of the leaves. The key of the
+// i-th leaf is NewKeyFromRoot(seed, label), where label is a fixed string
+// followed by i encoded as 8 bytes in big-endian order. The seed must have
+// enough entropy, as for NewKeyFromRoot.
+//
+// Each signature in sigs is the public key of the leaf followed by the
+// Ed25519 signature of the leaf. The tree uses the hashes of MerkleRoot, with
+// the public keys as chunk hashes, but it is padded to a power of two leaves
+// with the node H(prefix || 0x03), and the root is the top node of the tree.
+// The nodes are returned in tree, level by level starting at the leaves, so
+// that tree[len(tree)-1] is the root. Use TreeAuthPath to get the
+// authentication path of a leaf.
+//
+// It returns nil values if leaves is empty.
+func SignTree(seed []byte, leaves [][]byte) (root []byte, sigs [][]byte, tree [][]byte) {
+	if len(leaves) == 0 {
+		return nil, nil, nil
+	}
+
+	size := 1
+	for size < len(leaves) {
+		size *= 2
+	}
+	tree = make([][]byte, 0, 2*size-1)
+	sigs = make([][]byte, len(leaves))
+	label := make([]byte, len(signTreeLabel)+8)
+	copy(label, signTreeLabel)
+	for i := range leaves {
+		binary.BigEndian.PutUint64(label[len(signTreeLabel):], uint64(i))
+		priv := NewKeyFromRoot(seed, string(label))
+		pub := priv[SeedSize:]
+		sigs[i] = append(append(make([]byte, 0, TreeSignatureSize), pub...), Sign(priv, leaves[i])...)
+		tree = append(tree, signTreeNode(merkleLeafTag, pub))
+	}
+	pad := signTreeNode(merklePadTag)
+	for len(tree) < size {
+		tree = append(tree, pad)
+	}
+
+	for off, width := 0, size; width > 1; off, width = off+width, width/2 {
+		for i := off; i < off+width; i += 2 {
+			tree = append(tree, signTreeNode(merkleInteriorTag, tree[i], tree[i+1]))
+		}
+	}
+	return tree[len(tree)-1], sigs, tree
+}
+
+// TreeAuthPath returns the authentication path of the leaf at leafIndex in
+// a tree returned by SignTree, i.e., the siblings of the nodes on the path
+// from the leaf to the root. It returns nil if the tree is malformed or the
+// index is out of range.
+func TreeAuthPath(tree [][]byte, leafIndex int) [][]byte {
+	size := (len(tree) + 1) / 2
+	if size == 0 || size&(size-1) != 0 || len(tree) != 2*size-1 ||
+		leafIndex < 0 || leafIndex >= size {
+		return nil
+	}
+	path := [][]byte{}
+	for off, width := 0, size; width > 1; off, width = off+width, width/2 {
+		path = append(path, tree[off+(leafIndex^1)])
+		leafIndex /= 2
+	}
+	return path
+}
+
+// VerifyTreeLeaf returns true if sig, as returned by SignTree, is a valid
+// signature of leaf and its public key is the leaf at leafIndex of the tree
+// with the given root, according to the authentication path.
+func VerifyTreeLeaf(root []byte, leafIndex int, leaf, sig []byte, authPath [][]byte) bool {
+	if len(sig) != TreeSignatureSize || len(authPath) > 62 ||
+		leafIndex < 0 || leafIndex>>len(authPath) != 0 {
+		return false
+	}
+	pub := PublicKey(sig[:PublicKeySize])
+	node := signTreeNode(merkleLeafTag, pub)
+	for _, sibling := range authPath {
+		if leafIndex%2 == 0 {
+			node = signTreeNode(merkleInteriorTag, node, sibling)
+		} else {
+			node = signTreeNode(merkleInteriorTag, sibling, node)
+		}
+		leafIndex /= 2
+	}
+	return bytes.Equal(node, root) && Verify(pub, leaf, sig[PublicKeySize:])
+}
+
+// signTreeNode returns H(prefix || tag || data[0] || data[1] || ...), where
+// prefix = byte(len(signTreeDomain)) || signTreeDomain, as in MerkleRoot.
+func signTreeNode(tag byte, data ...[]byte) []byte {
+	H := sha512.New()
+	_, _ = H.Write([]byte{byte(len(signTreeDomain))})
+	_, _ = H.Write([]byte(signTreeDomain))
+	_, _ = H.Write([]byte{tag})
+	for _, d := range data {
+		_, _ = H.Write(d)
+	}
+	return H.Sum(nil)
+}
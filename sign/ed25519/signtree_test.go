@@ -0,0 +1,80 @@
+package ed25519_test
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed25519"
+)
+
+func TestSignTree(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	_, _ = rand.Read(seed)
+
+	for _, n := range []int{1, 2, 5, 8} {
+		leaves := make([][]byte, n)
+		for i := range leaves {
+			leaves[i] = []byte(fmt.Sprintf("leaf %v", i))
+		}
+		root, sigs, tree := ed25519.SignTree(seed, leaves)
+
+		for i := range leaves {
+			path := ed25519.TreeAuthPath(tree, i)
+			got := ed25519.VerifyTreeLeaf(root, i, leaves[i], sigs[i], path)
+			want := true
+			if got != want {
+				test.ReportError(t, got, want, n, i)
+			}
+
+			// Wrong leaf.
+			got = ed25519.VerifyTreeLeaf(root, i, []byte("other leaf"), sigs[i], path)
+			want = false
+			if got != want {
+				test.ReportError(t, got, want, n, i)
+			}
+
+			if len(path) == 0 {
+				continue
+			}
+
+			// Wrong index.
+			got = ed25519.VerifyTreeLeaf(root, i^1, leaves[i], sigs[i], path)
+			if got != want {
+				test.ReportError(t, got, want, n, i)
+			}
+
+			// Tampered authentication path.
+			bad := append([][]byte{}, path...)
+			bad[len(bad)-1] = append([]byte{}, bad[len(bad)-1]...)
+			bad[len(bad)-1][0] ^= 1
+			got = ed25519.VerifyTreeLeaf(root, i, leaves[i], sigs[i], bad)
+			if got != want {
+				test.ReportError(t, got, want, n, i)
+			}
+
+			// Truncated authentication path.
+			got = ed25519.VerifyTreeLeaf(root, i, leaves[i], sigs[i], path[1:])
+			if got != want {
+				test.ReportError(t, got, want, n, i)
+			}
+		}
+
+		// The same seed gives the same tree.
+		root2, _, _ := ed25519.SignTree(seed, leaves)
+		if got, want := string(root2), string(root); got != want {
+			test.ReportError(t, got, want, n)
+		}
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		root, sigs, tree := ed25519.SignTree(seed, nil)
+		if root != nil || sigs != nil || tree != nil {
+			test.ReportError(t, root, nil)
+		}
+		if got := ed25519.TreeAuthPath([][]byte{{0}, {1}}, 0); got != nil {
+			test.ReportError(t, got, nil)
+		}
+	})
+}
@@ -0,0 +1,38 @@
+package ed25519
+
+import (
+	"crypto/sha512"
+	"errors"
+	"hash"
+	"strconv"
+)
+
+var errFaultDetected = errors.New("ed25519: signature failed verification")
+
+// SignVerified is as Sign, but it verifies the signature before returning
+// it. If a fault corrupted the computation, the signature could leak the
+// private key; in that case, the verification fails and SignVerified returns
+// an error instead of the signature. This roughly doubles the cost of
+// signing. An error is also returned if the public key held in privateKey
+// does not match its seed. It will panic if len(privateKey) is not
+// PrivateKeySize.
+func SignVerified(privateKey PrivateKey, message []byte) ([]byte, error) {
+	if l := len(privateKey); l != PrivateKeySize {
+		panic("ed25519: bad private key length: " + strconv.Itoa(l))
+	}
+	H := sha512.New()
+	var key expandedKey
+	key.expand(H, privateKey)
+	return key.signVerified(H, message)
+}
+
+// signVerified signs message with key, using H as scratch, and verifies the
+// signature as SignVerified does.
+func (key *expandedKey) signVerified(H hash.Hash, message []byte) ([]byte, error) {
+	signature := make([]byte, SignatureSize)
+	key.sign(H, signature, message, nil, false)
+	if !Verify(key.public, message, signature) {
+		return nil, errFaultDetected
+	}
+	return signature, nil
+}
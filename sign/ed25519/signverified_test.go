@@ -0,0 +1,45 @@
+package ed25519
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+func TestSignVerified(t *testing.T) {
+	const testTimes = 1 << 6
+	for i := 0; i < testTimes; i++ {
+		_, priv, err := GenerateKey(rand.Reader)
+		test.CheckNoErr(t, err, "failed to generate key")
+		msg := make([]byte, i)
+		_, _ = rand.Read(msg)
+
+		got, err := SignVerified(priv, msg)
+		test.CheckNoErr(t, err, "SignVerified failed")
+		if want := Sign(priv, msg); !bytes.Equal(got, want) {
+			test.ReportError(t, got, want, msg)
+		}
+	}
+
+	_, priv, _ := GenerateKey(rand.Reader)
+	other, _, _ := GenerateKey(rand.Reader)
+	msg := []byte("message")
+	// Inject faults into the expanded key before signing.
+	for _, fault := range []func(key *expandedKey){
+		func(key *expandedKey) { key.s[7] ^= 0x10 },
+		func(key *expandedKey) { key.public = other },
+	} {
+		var key expandedKey
+		H := sha512.New()
+		key.expand(H, priv)
+		fault(&key)
+		sig, err := key.signVerified(H, msg)
+		test.CheckIsErr(t, err, "SignVerified should fail on a fault")
+		if sig != nil {
+			test.ReportError(t, sig, nil)
+		}
+	}
+}
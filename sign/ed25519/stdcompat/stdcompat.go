@@ -0,0 +1,95 @@
+// Package stdcompat provides Ed25519 signing and verification functions that
+// behave exactly as the ones of the crypto/ed25519 package of the standard
+// library, including their panic conditions, but are implemented on top of
+// the github.com/cloudflare/circl/sign/ed25519 package.
+//
+// Differences with the circl package are in the verification: as the
+// standard library does, public keys with a non-canonical encoding of the
+// y-coordinate (i.e., y >= p), or encoding x=0 with the sign bit set, are
+// accepted, and the key is hashed as given.
+package stdcompat
+
+import (
+	stded25519 "crypto/ed25519"
+	"crypto/sha512"
+	"strconv"
+
+	"github.com/cloudflare/circl/sign/ed25519"
+)
+
+const (
+	// PublicKeySize is the size, in bytes, of public keys.
+	PublicKeySize = stded25519.PublicKeySize
+	// PrivateKeySize is the size, in bytes, of private keys.
+	PrivateKeySize = stded25519.PrivateKeySize
+	// SignatureSize is the size, in bytes, of signatures.
+	SignatureSize = stded25519.SignatureSize
+)
+
+type (
+	// PublicKey is the type of Ed25519 public keys of the standard library.
+	PublicKey = stded25519.PublicKey
+	// PrivateKey is the type of Ed25519 private keys of the standard library.
+	PrivateKey = stded25519.PrivateKey
+)
+
+// Sign signs the message with privateKey and returns a signature. It will
+// panic if len(privateKey) is not PrivateKeySize.
+func Sign(privateKey PrivateKey, message []byte) []byte {
+	if l := len(privateKey); l != PrivateKeySize {
+		panic("ed25519: bad private key length: " + strconv.Itoa(l))
+	}
+	return ed25519.Sign(ed25519.PrivateKey(privateKey), message)
+}
+
+// Verify reports whether sig is a valid signature of message by publicKey.
+// It will panic if len(publicKey) is not PublicKeySize.
+func Verify(publicKey PublicKey, message, sig []byte) bool {
+	if l := len(publicKey); l != PublicKeySize {
+		panic("ed25519: bad public key length: " + strconv.Itoa(l))
+	}
+	if len(sig) != SignatureSize || sig[63]&224 != 0 {
+		return false
+	}
+
+	h := sha512.New()
+	_, _ = h.Write(sig[:32])
+	_, _ = h.Write(publicKey)
+	_, _ = h.Write(message)
+	var k ed25519.Scalar
+	_ = k.SetUniformBytes(h.Sum(nil))
+
+	A := canonicalKey(publicKey)
+	return ed25519.VerifyChallenge(A[:], sig[:32], sig[32:], k.Bytes())
+}
+
+// canonicalKey returns the canonical encoding of the point encoded by pub,
+// which can have a y-coordinate in the range [p, 2^255), or can encode x=0
+// with the sign bit set.
+func canonicalKey(pub []byte) (A [PublicKeySize]byte) {
+	copy(A[:], pub)
+	sign := A[31] >> 7
+	A[31] &= 0x7f
+
+	// y >= p = 2^255-19 iff all bits from 5 to 254 are set, and the lowest
+	// byte is at least 0xed.
+	isOnes := A[31] == 0x7f
+	for i := 1; i < 31; i++ {
+		isOnes = isOnes && A[i] == 0xff
+	}
+	if isOnes && A[0] >= 0xed {
+		A = [PublicKeySize]byte{A[0] - 0xed}
+	}
+
+	// x=0 iff y=1 or y=-1, in which case the sign bit is ignored.
+	isZero := true
+	for i := 1; i < 32; i++ {
+		isZero = isZero && A[i] == 0
+	}
+	isOne := isZero && A[0] == 0x01
+	isMinusOne := isOnes && A[0] == 0xec
+	if !isOne && !isMinusOne {
+		A[31] |= sign << 7
+	}
+	return A
+}
@@ -0,0 +1,108 @@
+package stdcompat_test
+
+import (
+	"bytes"
+	stded25519 "crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed25519"
+	"github.com/cloudflare/circl/sign/ed25519/stdcompat"
+)
+
+func TestCompat(t *testing.T) {
+	const testTimes = 1 << 8
+	msg := make([]byte, 64)
+	for i := 0; i < testTimes; i++ {
+		pub, priv, _ := stded25519.GenerateKey(rand.Reader)
+		_, _ = rand.Read(msg)
+
+		got := stdcompat.Sign(priv, msg)
+		want := stded25519.Sign(priv, msg)
+		if !bytes.Equal(got, want) {
+			test.ReportError(t, got, want, priv, msg)
+		}
+
+		bad := bytes.Clone(want)
+		bad[i%len(bad)] ^= byte(1 + i%7)
+		for _, sig := range [][]byte{want, bad, want[:63]} {
+			got, want := stdcompat.Verify(pub, msg, sig), stded25519.Verify(pub, msg, sig)
+			if got != want {
+				test.ReportError(t, got, want, pub, msg, sig)
+			}
+		}
+	}
+}
+
+// TestCompatEdgeKeys checks public keys that crypto/ed25519 accepts, but
+// the circl package rejects as non-canonical.
+func TestCompatEdgeKeys(t *testing.T) {
+	encode := func(low, high byte, fill byte) []byte {
+		b := bytes.Repeat([]byte{fill}, stdcompat.PublicKeySize)
+		b[0], b[31] = low, high
+		return b
+	}
+	keys := [][]byte{
+		encode(0x01, 0x00, 0x00), // y=1, the identity.
+		encode(0x01, 0x80, 0x00), // y=1 and sign bit set.
+		encode(0xee, 0x7f, 0xff), // y=p+1, the identity.
+		encode(0xee, 0xff, 0xff), // y=p+1 and sign bit set.
+		encode(0xec, 0x7f, 0xff), // y=p-1, point of order 2.
+		encode(0xec, 0xff, 0xff), // y=p-1 and sign bit set.
+		encode(0xed, 0x7f, 0xff), // y=p, point of order 4.
+		encode(0xed, 0xff, 0xff), // y=p and sign bit set.
+		encode(0xff, 0xff, 0xff), // y=p+18 and sign bit set.
+	}
+
+	msg := []byte("message")
+	seed := make([]byte, stded25519.SeedSize)
+	for i := 0; i < 16; i++ {
+		// For public keys encoding the identity, (R,S) is a valid signature
+		// if R=[S]B. Such a pair is obtained from the secret scalar of a
+		// key pair and its public key.
+		_, _ = rand.Read(seed)
+		priv := stded25519.NewKeyFromSeed(seed)
+		h := sha512.Sum512(seed)
+		h[0] &= 248
+		h[31] = (h[31] & 127) | 64
+		var s ed25519.Scalar
+		_ = s.SetUniformBytes(append(h[:32], make([]byte, 32)...))
+		sigs := [][]byte{
+			append(bytes.Clone(priv[32:]), s.Bytes()...),
+			stded25519.Sign(priv, msg),
+		}
+
+		for _, pub := range keys {
+			for _, sig := range sigs {
+				got := stdcompat.Verify(pub, msg, sig)
+				want := stded25519.Verify(pub, msg, sig)
+				if got != want {
+					test.ReportError(t, got, want, pub, sig)
+				}
+			}
+		}
+	}
+}
+
+// TestCompatPanics checks that the panic conditions match. The panic
+// messages are not compared, as they differ among Go versions.
+func TestCompatPanics(t *testing.T) {
+	for _, n := range []int{0, 32, 64, 65} {
+		key := make([]byte, n)
+		got := test.CheckPanic(func() { stdcompat.Sign(key, nil) }) == nil
+		want := test.CheckPanic(func() { stded25519.Sign(key, nil) }) == nil
+		if got != want {
+			test.ReportError(t, got, want, n)
+		}
+	}
+	for _, n := range []int{0, 31, 32, 64} {
+		key := make([]byte, n)
+		got := test.CheckPanic(func() { stdcompat.Verify(key, nil, nil) }) == nil
+		want := test.CheckPanic(func() { stded25519.Verify(key, nil, nil) }) == nil
+		if got != want {
+			test.ReportError(t, got, want, n)
+		}
+	}
+}
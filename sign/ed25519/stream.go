@@ -0,0 +1,37 @@
+package ed25519
+
+import (
+	"crypto/sha512"
+	"hash"
+)
+
+// StreamVerifier verifies Ed25519ph signatures of messages that are written
+// to it incrementally, so the message is never held entirely in memory.
+// It implements io.Writer.
+type StreamVerifier struct {
+	public PublicKey
+	ctx    string
+	h      hash.Hash
+}
+
+// NewStreamVerifier returns a StreamVerifier for Ed25519ph signatures under
+// the public key and the context string, which can be empty.
+func NewStreamVerifier(public PublicKey, context string) *StreamVerifier {
+	return &StreamVerifier{public: public, ctx: context, h: sha512.New()}
+}
+
+// Write feeds more data of the message into the prehash. It never returns
+// an error.
+func (v *StreamVerifier) Write(p []byte) (int, error) { return v.h.Write(p) }
+
+// Verify returns true if the signature is a valid Ed25519ph signature of the
+// message written so far. Failure cases are as for VerifyPh, or when the
+// context is longer than ContextMaxSize. The verifier
+// state is not modified, so more data can be written afterwards.
+func (v *StreamVerifier) Verify(signature []byte) bool {
+	if len(v.ctx) > ContextMaxSize {
+		return false
+	}
+	PHM := v.h.Sum(nil)
+	return verifyPHM(v.public, PHM, signature, []byte(v.ctx), true)
+}
@@ -0,0 +1,60 @@
+package ed25519_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	mrand "math/rand"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed25519"
+)
+
+func TestStreamVerifier(t *testing.T) {
+	const testTimes = 1 << 6
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	test.CheckNoErr(t, err, "GenerateKey failed")
+
+	for i := 0; i < testTimes; i++ {
+		msg := make([]byte, mrand.Intn(1<<12)) //nolint:gosec
+		_, _ = rand.Read(msg)
+		ctx := ""
+		if i%2 == 1 {
+			ctx = "a context"
+		}
+		sig := ed25519.SignPh(priv, msg, ctx)
+		if i%4 == 3 {
+			sig[0] ^= 0x01
+		}
+
+		v := ed25519.NewStreamVerifier(pub, ctx)
+		for rest := msg; len(rest) > 0; {
+			n := 1 + mrand.Intn(len(rest)) //nolint:gosec
+			_, err := v.Write(rest[:n])
+			test.CheckNoErr(t, err, "Write failed")
+			rest = rest[n:]
+		}
+
+		got := v.Verify(sig)
+		want := ed25519.VerifyPh(pub, msg, sig, ctx)
+		if got != want {
+			test.ReportError(t, got, want, i, ctx)
+		}
+	}
+
+	t.Run("copy", func(t *testing.T) {
+		msg := make([]byte, 1<<16)
+		_, _ = rand.Read(msg)
+		sig := ed25519.SignPh(priv, msg, "")
+
+		v := ed25519.NewStreamVerifier(pub, "")
+		_, err := io.Copy(v, bytes.NewReader(msg))
+		test.CheckNoErr(t, err, "io.Copy failed")
+		got := v.Verify(sig)
+		want := true
+		if got != want {
+			test.ReportError(t, got, want)
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package ed25519
+
+import "bytes"
+
+// VerifyThreshold returns true if at least k of the signatures are valid
+// signatures of the message by distinct signers. Each signature is checked
+// against the signers that have not matched a previous signature, so a
+// signer is counted at most once even if it signed several times, or if its
+// public key appears several times in signers. It also returns matched,
+// which holds the indices in signers of the signers of the valid
+// signatures, in the order of sigs. Invalid signatures are ignored.
+// It returns false if k is not positive.
+func VerifyThreshold(signers []PublicKey, k int, message []byte, sigs [][]byte) (ok bool, matched []int) {
+	if k <= 0 {
+		return false, nil
+	}
+
+	used := make([]bool, len(signers))
+	var v VerifyContext
+	for _, sig := range sigs {
+		for i, pub := range signers {
+			if used[i] || !v.Verify(pub, message, sig) {
+				continue
+			}
+			for j := range signers {
+				if bytes.Equal(signers[j], pub) {
+					used[j] = true
+				}
+			}
+			matched = append(matched, i)
+			break
+		}
+	}
+	return len(matched) >= k, matched
+}
@@ -0,0 +1,55 @@
+package ed25519_test
+
+import (
+	"crypto/rand"
+	"reflect"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed25519"
+)
+
+func TestVerifyThreshold(t *testing.T) {
+	const n = 5
+	msg := []byte("transfer 100 coins")
+	signers := make([]ed25519.PublicKey, n)
+	sigs := make([][]byte, n)
+	for i := range signers {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		test.CheckNoErr(t, err, "failed to generate key")
+		signers[i] = pub
+		sigs[i] = ed25519.Sign(priv, msg)
+	}
+	_, outsider, _ := ed25519.GenerateKey(rand.Reader)
+	forged := ed25519.Sign(outsider, msg)
+
+	testCases := []struct {
+		name    string
+		signers []ed25519.PublicKey
+		k       int
+		sigs    [][]byte
+		ok      bool
+		matched []int
+	}{
+		{"exactly_k", signers, 3, [][]byte{sigs[4], sigs[0], sigs[2]}, true, []int{4, 0, 2}},
+		{"above_k", signers, 2, [][]byte{sigs[1], sigs[3], sigs[0]}, true, []int{1, 3, 0}},
+		{"below_k", signers, 3, [][]byte{sigs[1], sigs[3]}, false, []int{1, 3}},
+		{"invalid", signers, 3, [][]byte{sigs[1], forged, sigs[3]}, false, []int{1, 3}},
+		{"duplicate_sig", signers, 3, [][]byte{sigs[2], sigs[2], sigs[2]}, false, []int{2}},
+		{
+			"duplicate_signer",
+			[]ed25519.PublicKey{signers[0], signers[1], signers[0]},
+			2, [][]byte{sigs[0], sigs[0]}, false, []int{0},
+		},
+		{"unknown_signer", signers[:2], 1, [][]byte{sigs[2]}, false, nil},
+		{"zero_k", signers, 0, sigs, false, nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, matched := ed25519.VerifyThreshold(tc.signers, tc.k, msg, tc.sigs)
+			if ok != tc.ok || !reflect.DeepEqual(matched, tc.matched) {
+				test.ReportError(t, ok, tc.ok, matched, tc.matched)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package ed25519
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+// TestTrace checks the intermediate values of signing and verifying against
+// reference values. They are read from the scratch buffers of expandedKey
+// and VerifyContext, which keep them after each call, so no trace type or
+// hook is needed in the package.
+func TestTrace(t *testing.T) {
+	// Test vectors 1 and 2 of RFC 8032, Section 7.1.
+	for _, v := range []struct{ seed, msg, sig string }{
+		{
+			"9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60", "",
+			"e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e06522490155" +
+				"5fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b",
+		},
+		{
+			"4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb", "72",
+			"92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da" +
+				"085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c00",
+		},
+	} {
+		seed, _ := hex.DecodeString(v.seed)
+		msg, _ := hex.DecodeString(v.msg)
+		sig, _ := hex.DecodeString(v.sig)
+		priv := NewKeyFromSeed(seed)
+		pub := PublicKey(priv[SeedSize:])
+
+		// Reference values computed with math/big.
+		ell := new(big.Int).SetBytes(reverse(order[:]))
+		h := sha512.Sum512(seed)
+		h[0] &= 248
+		h[31] &= 127
+		h[31] |= 64
+		scalar := h[:paramB]
+		nonce := hashModOrder(ell, h[paramB:], msg)
+		hRAM := hashModOrder(ell, sig[:paramB], pub, msg)
+
+		var key expandedKey
+		var vc VerifyContext
+		H := sha512.New()
+		key.expand(H, priv)
+		got := make([]byte, SignatureSize)
+		key.sign(H, got, msg, nil, false)
+		ok := vc.Verify(pub, msg, got)
+
+		test.CheckOk(bytes.Equal(got, sig) && ok, "signature does not match RFC 8032", t)
+		for _, c := range []struct {
+			name      string
+			got, want []byte
+		}{
+			{"scalar", key.s[:], scalar},
+			{"nonce", key.r[:paramB], nonce},
+			{"R", got[:paramB], sig[:paramB]},
+			{"hRAM", key.hRAM[:paramB], hRAM},
+			{"S", got[paramB:], sig[paramB:]},
+			{"verify R", vc.encR[:], sig[:paramB]},
+			{"verify hRAM", vc.hRAM[:paramB], hRAM},
+		} {
+			if !bytes.Equal(c.got, c.want) {
+				test.ReportError(t, c.got, c.want, c.name, v.seed)
+			}
+		}
+
+		// S = r + k*s mod L
+		wantS := new(big.Int).Mul(leToInt(hRAM), leToInt(scalar))
+		wantS.Add(wantS, leToInt(nonce)).Mod(wantS, ell)
+		if leToInt(got[paramB:]).Cmp(wantS) != 0 {
+			test.ReportError(t, got[paramB:], wantS, v.seed)
+		}
+	}
+}
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
+
+func leToInt(b []byte) *big.Int { return new(big.Int).SetBytes(reverse(b)) }
+
+// hashModOrder returns SHA-512(in...) mod ell in little-endian order.
+func hashModOrder(ell *big.Int, in ...[]byte) []byte {
+	h := sha512.New()
+	for _, b := range in {
+		_, _ = h.Write(b)
+	}
+	k := leToInt(h.Sum(nil))
+	k.Mod(k, ell)
+	return reverse(k.FillBytes(make([]byte, paramB)))
+}
@@ -0,0 +1,111 @@
+package ed25519
+
+import (
+	"container/list"
+	"crypto/hmac"
+	"crypto/sha256"
+	"hash"
+	"sync"
+)
+
+// VerifyCache remembers the results of verifying signatures, so that
+// verifying the same (public key, message, signature) triple again costs a
+// hash computation instead of a scalar multiplication. Both valid and invalid
+// results are cached. Once the cache is full, the least recently used result
+// is evicted.
+//
+// Results are indexed by the SHA-256 digest of the triple, so a collision
+// would return the result of a different triple. This is not a concern for
+// inputs that are not attacker-chosen; otherwise, a secret key should be
+// passed to NewVerifyCache, which makes the index an HMAC-SHA256 of the
+// triple instead.
+//
+// A VerifyCache is safe for concurrent use by multiple goroutines.
+type VerifyCache struct {
+	mu      sync.Mutex
+	size    int
+	key     []byte
+	lru     *list.List
+	entries map[[sha256.Size]byte]*list.Element
+}
+
+type verifyCacheEntry struct {
+	id [sha256.Size]byte
+	ok bool
+}
+
+// NewVerifyCache returns a VerifyCache holding at most size results, which
+// must be positive. If key is non-empty, results are indexed with
+// HMAC-SHA256 under the key.
+func NewVerifyCache(size int, key []byte) *VerifyCache {
+	if size <= 0 {
+		panic("ed25519: non-positive cache size")
+	}
+	return &VerifyCache{
+		size:    size,
+		key:     append([]byte(nil), key...),
+		lru:     list.New(),
+		entries: make(map[[sha256.Size]byte]*list.Element),
+	}
+}
+
+// Verify returns true if the signature is valid, as Verify does. If the
+// result for these inputs is cached, it is returned without verifying the
+// signature again.
+func (c *VerifyCache) Verify(public PublicKey, message, sig []byte) bool {
+	// The lengths are fixed so that the concatenation is unambiguous; other
+	// inputs are rejected by Verify anyway.
+	if len(public) != PublicKeySize || len(sig) != SignatureSize {
+		return false
+	}
+
+	var H hash.Hash
+	if len(c.key) > 0 {
+		H = hmac.New(sha256.New, c.key)
+	} else {
+		H = sha256.New()
+	}
+	_, _ = H.Write(public)
+	_, _ = H.Write(sig)
+	_, _ = H.Write(message)
+	var id [sha256.Size]byte
+	H.Sum(id[:0])
+
+	c.mu.Lock()
+	if e, found := c.entries[id]; found {
+		c.lru.MoveToFront(e)
+		ok := e.Value.(*verifyCacheEntry).ok
+		c.mu.Unlock()
+		return ok
+	}
+	c.mu.Unlock()
+
+	ok := Verify(public, message, sig)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, found := c.entries[id]; !found {
+		c.entries[id] = c.lru.PushFront(&verifyCacheEntry{id, ok})
+		if c.lru.Len() > c.size {
+			last := c.lru.Back()
+			c.lru.Remove(last)
+			delete(c.entries, last.Value.(*verifyCacheEntry).id)
+		}
+	}
+	return ok
+}
+
+// Len returns the number of cached results.
+func (c *VerifyCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Purge removes all the cached results.
+func (c *VerifyCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Init()
+	c.entries = make(map[[sha256.Size]byte]*list.Element)
+}
@@ -0,0 +1,79 @@
+package ed25519_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed25519"
+)
+
+func TestVerifyCache(t *testing.T) {
+	const numKeys = 8
+	pubs := make([]ed25519.PublicKey, numKeys)
+	msgs := make([][]byte, numKeys)
+	sigs := make([][]byte, numKeys)
+	for i := range pubs {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		test.CheckNoErr(t, err, "failed to generate key")
+		msgs[i] = []byte{byte(i), 'm', 's', 'g'}
+		sigs[i] = ed25519.Sign(priv, msgs[i])
+		if i%2 == 1 {
+			// Odd entries have invalid signatures.
+			sigs[i][0] ^= 1
+		}
+		pubs[i] = pub
+	}
+
+	for _, key := range [][]byte{nil, []byte("secret key")} {
+		c := ed25519.NewVerifyCache(numKeys/2, key)
+		for round := 0; round < 3; round++ {
+			for i := range pubs {
+				want := ed25519.Verify(pubs[i], msgs[i], sigs[i])
+				got := c.Verify(pubs[i], msgs[i], sigs[i])
+				if got != want {
+					test.ReportError(t, got, want, round, i)
+				}
+				// Repeated inputs are served from the cache.
+				got = c.Verify(pubs[i], msgs[i], sigs[i])
+				if got != want {
+					test.ReportError(t, got, want, round, i)
+				}
+				// A different message is not a cache hit.
+				got = c.Verify(pubs[i], msgs[(i+2)%numKeys], sigs[i])
+				if got {
+					test.ReportError(t, got, false, round, i)
+				}
+			}
+			if got, want := c.Len(), numKeys/2; got != want {
+				test.ReportError(t, got, want, round)
+			}
+		}
+
+		c.Purge()
+		if got, want := c.Len(), 0; got != want {
+			test.ReportError(t, got, want)
+		}
+		if got := c.Verify(pubs[0], msgs[0], sigs[0]); !got {
+			test.ReportError(t, got, true)
+		}
+	}
+}
+
+func BenchmarkVerifyCache(b *testing.B) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	msg := []byte("Hello, world!")
+	sig := ed25519.Sign(priv, msg)
+	c := ed25519.NewVerifyCache(16, nil)
+
+	b.Run("Verify", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ed25519.Verify(pub, msg, sig)
+		}
+	})
+	b.Run("VerifyCache", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			c.Verify(pub, msg, sig)
+		}
+	})
+}
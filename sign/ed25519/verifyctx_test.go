@@ -0,0 +1,60 @@
+package ed25519_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/circl/sign/ed25519"
+)
+
+func TestVerifyContext(t *testing.T) {
+	var zero zeroReader
+	pub, priv, err := ed25519.GenerateKey(zero)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := []byte("Hello, world!")
+	signature := ed25519.Sign(priv, message)
+
+	var v ed25519.VerifyContext
+	if !v.Verify(pub, message, signature) {
+		t.Fatal("valid signature rejected")
+	}
+	bad := append([]byte{}, signature...)
+	bad[0] ^= 1
+	if v.Verify(pub, message, bad) {
+		t.Fatal("invalid signature accepted")
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if !v.Verify(pub, message, signature) {
+			t.Fatal("valid signature rejected")
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("got %v allocations per Verify, want 0", allocs)
+	}
+}
+
+func BenchmarkVerifyContext(b *testing.B) {
+	var zero zeroReader
+	pub, priv, err := ed25519.GenerateKey(zero)
+	if err != nil {
+		b.Fatal(err)
+	}
+	message := []byte("Hello, world!")
+	signature := ed25519.Sign(priv, message)
+
+	b.Run("Verify", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			ed25519.Verify(pub, message, signature)
+		}
+	})
+	b.Run("VerifyContext", func(b *testing.B) {
+		var v ed25519.VerifyContext
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			v.Verify(pub, message, signature)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package ed25519
+
+import "crypto/sha512"
+
+// VerifyDiagnostic is as Verify, but on failure it also returns a
+// human-readable reason, meant for debugging. The reason is one of:
+//
+//	"bad public key length"
+//	"bad signature length"
+//	"S not reduced mod L"
+//	"public key not canonical"
+//	"public key not on curve"
+//	"R not canonical"
+//	"R not on curve"
+//	"recomputed R mismatch"
+//
+// The last one is returned for well-formed inputs that fail the verification
+// equation, e.g., because the message or the public key is not the one that
+// was signed. On success, reason is empty. Since all the inputs are public,
+// this function is not constant time.
+func VerifyDiagnostic(public PublicKey, message, sig []byte) (ok bool, reason string) {
+	switch {
+	case len(public) != PublicKeySize:
+		return false, "bad public key length"
+	case len(sig) != SignatureSize:
+		return false, "bad signature length"
+	case !isLessThanOrder(sig[paramB:]):
+		return false, "S not reduced mod L"
+	}
+
+	var A, P pointR1
+	if !A.FromBytes(public) {
+		if A.fromBytesOpt(public, true) {
+			return false, "public key not canonical"
+		}
+		return false, "public key not on curve"
+	}
+
+	R, S := sig[:paramB], sig[paramB:]
+	H := sha512.New()
+	_, _ = H.Write(R)
+	_, _ = H.Write(public)
+	_, _ = H.Write(message)
+	hRAM := H.Sum(nil)
+	reduceModOrder(hRAM[:], true)
+
+	var encR [paramB]byte
+	if !checkEquation(&A, R, S, hRAM[:paramB], encR[:]) {
+		// A non-canonical R never matches the recomputed encoding.
+		if !P.FromBytes(R) {
+			if P.fromBytesOpt(R, true) {
+				return false, "R not canonical"
+			}
+			return false, "R not on curve"
+		}
+		return false, "recomputed R mismatch"
+	}
+	return true, ""
+}
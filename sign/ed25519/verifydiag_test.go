@@ -0,0 +1,45 @@
+package ed25519_test
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed25519"
+)
+
+func TestVerifyDiagnostic(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	test.CheckNoErr(t, err, "failed to generate key")
+	msg := []byte("message")
+	sig := ed25519.Sign(priv, msg)
+
+	hexDecode := func(s string) []byte { b, _ := hex.DecodeString(s); return b }
+	nonCanonical := hexDecode("edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f")
+	notOnCurve := hexDecode("9a0abec623cb5a234e49d892c272d5a827ff42077de3f2b474759d0434eda670")
+	order := hexDecode("edd3f55c1a631258d69cf7a2def9de1400000000000000000000000000000010")
+	withR := func(R []byte) []byte { return append(append([]byte{}, R...), sig[32:]...) }
+
+	for _, c := range []struct {
+		name, reason string
+		pub, msg     []byte
+		sig          []byte
+	}{
+		{"valid", "", pub, msg, sig},
+		{"pub_length", "bad public key length", pub[:31], msg, sig},
+		{"sig_length", "bad signature length", pub, msg, sig[:63]},
+		{"S_order", "S not reduced mod L", pub, msg, append(append([]byte{}, sig[:32]...), order...)},
+		{"pub_non_canonical", "public key not canonical", nonCanonical, msg, sig},
+		{"pub_not_on_curve", "public key not on curve", notOnCurve, msg, sig},
+		{"R_non_canonical", "R not canonical", pub, msg, withR(nonCanonical)},
+		{"R_not_on_curve", "R not on curve", pub, msg, withR(notOnCurve)},
+		{"wrong_message", "recomputed R mismatch", pub, []byte("other"), sig},
+	} {
+		ok, reason := ed25519.VerifyDiagnostic(c.pub, c.msg, c.sig)
+		want := ed25519.Verify(c.pub, c.msg, c.sig)
+		if ok != want || reason != c.reason {
+			test.ReportError(t, reason, c.reason, c.name, ok, want)
+		}
+	}
+}
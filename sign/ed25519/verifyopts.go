@@ -0,0 +1,126 @@
+package ed25519
+
+import (
+	"crypto/sha512"
+
+	fp "github.com/cloudflare/circl/math/fp25519"
+)
+
+// VerifyOptions selects the checks performed by VerifyWith, so that the
+// verification criteria can match those of another implementation. Which
+// signatures are accepted varies between implementations; see "Taming the
+// many EdDSAs" by Chalkias et al., https://eprint.iacr.org/2020/1244.
+//
+// The zero value is the policy of Verify: it rejects non-canonical
+// encodings, accepts public keys of small order, and uses the cofactorless
+// equation [S]B = R + [k]A.
+//
+// The options relate to Verify and to a strict verification as follows:
+//
+//	                     Verify (zero value)   strict
+//	AllowNonCanonicalA   false                 false
+//	AllowNonCanonicalR   false                 false
+//	RejectSmallOrderA    false                 true
+//	RequireCofactored    false                 false
+//
+// In all cases, S must be less than the order of the curve.
+type VerifyOptions struct {
+	// AllowNonCanonicalA accepts public keys encoded with a y-coordinate
+	// that is not reduced modulo p, or with the sign bit set for x = 0.
+	AllowNonCanonicalA bool
+	// AllowNonCanonicalR is as AllowNonCanonicalA for the point R of the
+	// signature.
+	AllowNonCanonicalR bool
+	// RejectSmallOrderA rejects public keys of order at most 8.
+	RejectSmallOrderA bool
+	// RequireCofactored uses the cofactored equation [8][S]B = [8]R + [8][k]A,
+	// which accepts signatures failing the cofactorless one only if A or R
+	// have a component of small order.
+	RequireCofactored bool
+}
+
+// DefaultVerifyOptions returns the options for which VerifyWith is
+// equivalent to Verify, that is, the zero value of VerifyOptions.
+func DefaultVerifyOptions() VerifyOptions { return VerifyOptions{} }
+
+// VerifyWith returns true if the signature is a valid Ed25519 signature of
+// the message under the public key, according to the checks selected by
+// opts.
+func VerifyWith(public PublicKey, message, sig []byte, opts VerifyOptions) bool {
+	if len(public) != PublicKeySize ||
+		len(sig) != SignatureSize ||
+		!isLessThanOrder(sig[paramB:]) {
+		return false
+	}
+
+	var A pointR1
+	if !A.fromBytesOpt(public, opts.AllowNonCanonicalA) {
+		return false
+	}
+	if opts.RejectSmallOrderA && A.isSmallOrder() {
+		return false
+	}
+
+	R, S := sig[:paramB], sig[paramB:]
+	H := sha512.New()
+	_, _ = H.Write(R)
+	_, _ = H.Write(public)
+	_, _ = H.Write(message)
+	hRAM := H.Sum(nil)
+	reduceModOrder(hRAM[:], true)
+
+	if !opts.AllowNonCanonicalR && !opts.RequireCofactored {
+		var encR [paramB]byte
+		return checkEquation(&A, R, S, hRAM[:paramB], encR[:])
+	}
+
+	var P, Q pointR1
+	if !P.fromBytesOpt(R, opts.AllowNonCanonicalR) {
+		return false
+	}
+	A.neg()
+	Q.doubleMult(&A, S, hRAM[:paramB])
+	if !opts.RequireCofactored {
+		return Q.isEqual(&P)
+	}
+
+	var negR pointR2
+	P.neg()
+	negR.fromR1(&P)
+	Q.add(&negR)
+	return Q.isSmallOrder()
+}
+
+// fromBytesOpt is as FromBytes, but if nonCanonical is true, it also
+// accepts encodings of y not reduced modulo p, and of x = 0 with the sign
+// bit set.
+func (P *pointR1) fromBytesOpt(k []byte, nonCanonical bool) bool {
+	if P.FromBytes(k) {
+		return true
+	}
+	if !nonCanonical {
+		return false
+	}
+	var y fp.Elt
+	copy(y[:], k)
+	y[fp.Size-1] &= 0x7F
+	fp.Modp(&y)
+	y[fp.Size-1] |= k[paramB-1] & 0x80
+	if P.FromBytes(y[:]) {
+		return true
+	}
+	// Only x = 0 is rejected because of the sign bit.
+	y[fp.Size-1] &= 0x7F
+	return P.FromBytes(y[:]) && fp.IsZero(&P.x)
+}
+
+// isSmallOrder returns true if [8]P is the identity.
+func (P *pointR1) isSmallOrder() bool {
+	var Q, id pointR1
+	Q = *P
+	Q.double()
+	Q.double()
+	Q.double()
+	id.SetIdentity()
+	return Q.isEqual(&id)
+}
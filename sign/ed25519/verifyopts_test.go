@@ -0,0 +1,168 @@
+package ed25519
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+type verifyOptsVector struct {
+	name    string
+	pub     PublicKey
+	msg     []byte
+	sig     []byte
+	needs   VerifyOptions // options the signature requires to be accepted.
+	small   bool          // A has small order, so RejectSmallOrderA rejects it.
+	invalid bool          // rejected regardless of the options.
+}
+
+// accepted returns whether v is accepted under the options.
+func (v *verifyOptsVector) accepted(o VerifyOptions) bool {
+	return !v.invalid &&
+		(!v.needs.AllowNonCanonicalA || o.AllowNonCanonicalA) &&
+		(!v.needs.AllowNonCanonicalR || o.AllowNonCanonicalR) &&
+		(!v.small || !o.RejectSmallOrderA) &&
+		(!v.needs.RequireCofactored || o.RequireCofactored)
+}
+
+// signWithKey returns a signature of msg by the scalar a under the encoded
+// public key pub, which may be any point.
+func signWithKey(t *testing.T, pub, a, msg []byte) []byte {
+	var R pointR1
+	r := make([]byte, 2*paramB)
+	_, err := rand.Read(r)
+	test.CheckNoErr(t, err, "failed to read random bytes")
+	reduceModOrder(r, true)
+	R.fixedMult(r[:paramB])
+
+	sig := make([]byte, SignatureSize)
+	err = R.ToBytes(sig[:paramB])
+	test.CheckNoErr(t, err, "failed to encode point")
+	H := sha512.New()
+	_, _ = H.Write(sig[:paramB])
+	_, _ = H.Write(pub)
+	_, _ = H.Write(msg)
+	k := H.Sum(nil)
+	reduceModOrder(k, true)
+	calculateS(sig[paramB:], r[:paramB], k[:paramB], a)
+	return sig
+}
+
+func verifyOptsVectors(t *testing.T) []verifyOptsVector {
+	pub, priv, err := GenerateKey(rand.Reader)
+	test.CheckNoErr(t, err, "failed to generate key")
+	msg := []byte("message")
+	honest := Sign(priv, msg)
+	tampered := Sign(priv, msg)
+	tampered[0] ^= 1
+
+	// The identity with canonical and non-canonical encodings, the latter
+	// being y = p+1, and x = 0 with the sign bit set.
+	idEnc := make([]byte, paramB)
+	idEnc[0] = 0x01
+	idNonCanonicalY := make([]byte, paramB)
+	idNonCanonicalY[0] = 0xee
+	for i := 1; i < paramB-1; i++ {
+		idNonCanonicalY[i] = 0xff
+	}
+	idNonCanonicalY[paramB-1] = 0x7f
+	idSignBit := make([]byte, paramB)
+	copy(idSignBit, idEnc)
+	idSignBit[paramB-1] |= 0x80
+	zeroS := make([]byte, paramB)
+
+	// A mixed-order key A = [a]B + T, where T has order 8. The message is
+	// chosen such that the cofactorless equation fails.
+	var A, T pointR1
+	enc, _ := hex.DecodeString("26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05")
+	if !T.FromBytes(enc) {
+		t.Fatal("bad point of order 8")
+	}
+	a := make([]byte, 2*paramB)
+	_, err = rand.Read(a)
+	test.CheckNoErr(t, err, "failed to read random bytes")
+	reduceModOrder(a, true)
+	var T2 pointR2
+	T2.fromR1(&T)
+	A.fixedMult(a[:paramB])
+	A.add(&T2)
+	mixedPub := make(PublicKey, PublicKeySize)
+	err = A.ToBytes(mixedPub)
+	test.CheckNoErr(t, err, "failed to encode point")
+	var mixedMsg, mixedSig []byte
+	for i := 0; ; i++ {
+		mixedMsg = []byte(fmt.Sprintf("message %v", i))
+		mixedSig = signWithKey(t, mixedPub, a[:paramB], mixedMsg)
+		if !Verify(mixedPub, mixedMsg, mixedSig) {
+			break
+		}
+	}
+
+	return []verifyOptsVector{
+		{name: "honest", pub: pub, msg: msg, sig: honest},
+		{name: "tampered", pub: pub, msg: msg, sig: tampered, invalid: true},
+		{
+			name: "mixedOrderA", pub: mixedPub, msg: mixedMsg, sig: mixedSig,
+			needs: VerifyOptions{RequireCofactored: true},
+		},
+		{
+			name: "smallOrderA", pub: idEnc, msg: msg, sig: append(idEnc, zeroS...),
+			small: true,
+		},
+		{
+			name: "nonCanonicalA", pub: idNonCanonicalY, msg: msg, sig: append(idEnc, zeroS...),
+			small: true, needs: VerifyOptions{AllowNonCanonicalA: true},
+		},
+		{
+			name: "signBitA", pub: idSignBit, msg: msg, sig: append(idEnc, zeroS...),
+			small: true, needs: VerifyOptions{AllowNonCanonicalA: true},
+		},
+		{
+			name: "nonCanonicalR", pub: idEnc, msg: msg, sig: append(idNonCanonicalY, zeroS...),
+			small: true, needs: VerifyOptions{AllowNonCanonicalR: true},
+		},
+		{
+			name: "signBitR", pub: idEnc, msg: msg, sig: append(idSignBit, zeroS...),
+			small: true, needs: VerifyOptions{AllowNonCanonicalR: true},
+		},
+		{
+			name: "nonCanonicalS", pub: pub, msg: msg, sig: append(honest[:paramB:paramB], order[:]...),
+			invalid: true,
+		},
+	}
+}
+
+func TestVerifyWith(t *testing.T) {
+	vectors := verifyOptsVectors(t)
+	for flags := 0; flags < 16; flags++ {
+		opts := VerifyOptions{
+			AllowNonCanonicalA: flags&1 != 0,
+			AllowNonCanonicalR: flags&2 != 0,
+			RejectSmallOrderA:  flags&4 != 0,
+			RequireCofactored:  flags&8 != 0,
+		}
+		for i := range vectors {
+			v := &vectors[i]
+			t.Run(fmt.Sprintf("%v/%+v", v.name, opts), func(t *testing.T) {
+				got := VerifyWith(v.pub, v.msg, v.sig, opts)
+				want := v.accepted(opts)
+				if got != want {
+					test.ReportError(t, got, want, v.pub, v.msg, v.sig)
+				}
+			})
+		}
+	}
+
+	for i := range vectors {
+		v := &vectors[i]
+		got := VerifyWith(v.pub, v.msg, v.sig, DefaultVerifyOptions())
+		want := Verify(v.pub, v.msg, v.sig)
+		if got != want {
+			test.ReportError(t, got, want, v.name)
+		}
+	}
+}
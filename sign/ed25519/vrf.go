@@ -0,0 +1,168 @@
+package ed25519
+
+import (
+	"crypto/sha512"
+	"crypto/subtle"
+	"hash"
+	"strconv"
+)
+
+// ECVRF-EDWARDS25519-SHA512-TAI parameters of RFC 9381.
+const (
+	// vrfSuite is the suite_string.
+	vrfSuite = 0x03
+	// vrfChallengeSize is cLen, the size in bytes of challenges.
+	vrfChallengeSize = 16
+	// VRFProofSize is the size in bytes of VRF proofs.
+	VRFProofSize = paramB + vrfChallengeSize + paramB
+	// VRFOutputSize is the size in bytes of VRF outputs.
+	VRFOutputSize = sha512.Size
+)
+
+// VRFProve returns the proof and the output of the verifiable random
+// function ECVRF-EDWARDS25519-SHA512-TAI of RFC 9381, evaluated on alpha
+// with privateKey. The output beta is a deterministic function of the key
+// and alpha, and the proof allows anyone holding the public key to check
+// it with VRFVerify. It will panic if len(privateKey) is not PrivateKeySize.
+//
+// Reference: https://www.rfc-editor.org/rfc/rfc9381
+func VRFProve(privateKey PrivateKey, alpha []byte) (proof, beta []byte) {
+	if l := len(privateKey); l != PrivateKeySize {
+		panic("ed25519: bad private key length: " + strconv.Itoa(l))
+	}
+	H := sha512.New()
+	var key expandedKey
+	key.expand(H, privateKey)
+
+	var hPoint, gamma, U, V pointR1
+	if !vrfEncodeToCurve(H, &hPoint, key.public, alpha) {
+		panic("ed25519: VRF encode to curve failed")
+	}
+	var hStr, gammaStr, uStr, vStr [paramB]byte
+	_ = hPoint.ToBytes(hStr[:])
+
+	// Gamma = [x]H
+	gamma.ctMult(&hPoint, key.s[:])
+	_ = gamma.ToBytes(gammaStr[:])
+
+	// k = SHA-512(prefix || point_to_string(H)) mod q
+	var k [2 * paramB]byte
+	H.Reset()
+	_, _ = H.Write(key.prefix[:])
+	_, _ = H.Write(hStr[:])
+	H.Sum(k[:0])
+	reduceModOrder(k[:], true)
+
+	// U = [k]B and V = [k]H
+	U.fixedMult(k[:paramB])
+	_ = U.ToBytes(uStr[:])
+	V.ctMult(&hPoint, k[:paramB])
+	_ = V.ToBytes(vStr[:])
+
+	var c [paramB]byte
+	vrfChallenge(H, c[:vrfChallengeSize], key.public, hStr[:], gammaStr[:], uStr[:], vStr[:])
+
+	// pi = point_to_string(Gamma) || c || (k + c*x mod q)
+	proof = make([]byte, VRFProofSize)
+	copy(proof, gammaStr[:])
+	copy(proof[paramB:], c[:vrfChallengeSize])
+	calculateS(proof[paramB+vrfChallengeSize:], k[:paramB], c[:], key.s[:])
+
+	return proof, vrfProofToHash(H, &gamma)
+}
+
+// VRFVerify checks that proof is a valid proof of VRFProve for alpha under
+// the public key, in which case it returns the output beta and true.
+// Otherwise, it returns false. Public keys of small order are rejected,
+// as with the validate_key option of RFC 9381.
+func VRFVerify(public PublicKey, alpha, proof []byte) (beta []byte, ok bool) {
+	if len(public) != PublicKeySize || len(proof) != VRFProofSize {
+		return nil, false
+	}
+	var Y, gamma, hPoint pointR1
+	if !Y.FromBytes(public) || Y.isSmallOrder() || !gamma.FromBytes(proof[:paramB]) {
+		return nil, false
+	}
+	var c, s [paramB]byte
+	copy(c[:], proof[paramB:paramB+vrfChallengeSize])
+	copy(s[:], proof[paramB+vrfChallengeSize:])
+	if !isLessThanOrder(s[:]) {
+		return nil, false
+	}
+
+	H := sha512.New()
+	if !vrfEncodeToCurve(H, &hPoint, public, alpha) {
+		return nil, false
+	}
+	var hStr, uStr, vStr [paramB]byte
+	negGamma := gamma
+	negGamma.neg()
+	points := []pointR1{hPoint, negGamma}
+	_ = hPoint.ToBytes(hStr[:])
+
+	// U = [s]B - [c]Y and V = [s]H - [c]Gamma
+	var U, V pointR1
+	Y.neg()
+	U.doubleMult(&Y, s[:], c[:])
+	_ = U.ToBytes(uStr[:])
+	V.multiMult(make([]byte, paramB), points, [][paramB]byte{s, c})
+	_ = V.ToBytes(vStr[:])
+
+	var cc [vrfChallengeSize]byte
+	vrfChallenge(H, cc[:], public, hStr[:], proof[:paramB], uStr[:], vStr[:])
+	if subtle.ConstantTimeCompare(cc[:], c[:vrfChallengeSize]) != 1 {
+		return nil, false
+	}
+	return vrfProofToHash(H, &gamma), true
+}
+
+// vrfEncodeToCurve implements ECVRF_encode_to_curve_try_and_increment with
+// the public key as salt. It returns false if no point was found, which
+// happens with negligible probability.
+func vrfEncodeToCurve(H hash.Hash, P *pointR1, public, alpha []byte) bool {
+	var h [sha512.Size]byte
+	for ctr := 0; ctr < 256; ctr++ {
+		H.Reset()
+		_, _ = H.Write([]byte{vrfSuite, 0x01})
+		_, _ = H.Write(public)
+		_, _ = H.Write(alpha)
+		_, _ = H.Write([]byte{byte(ctr), 0x00})
+		H.Sum(h[:0])
+		if P.FromBytes(h[:paramB]) {
+			P.double()
+			P.double()
+			P.double()
+			return true
+		}
+	}
+	return false
+}
+
+// vrfChallenge implements ECVRF_challenge_generation, writing the challenge
+// of vrfChallengeSize bytes to c.
+func vrfChallenge(H hash.Hash, c []byte, points ...[]byte) {
+	var h [sha512.Size]byte
+	H.Reset()
+	_, _ = H.Write([]byte{vrfSuite, 0x02})
+	for _, p := range points {
+		_, _ = H.Write(p)
+	}
+	_, _ = H.Write([]byte{0x00})
+	H.Sum(h[:0])
+	copy(c, h[:vrfChallengeSize])
+}
+
+// vrfProofToHash implements ECVRF_proof_to_hash, returning the output of
+// the VRF for the point Gamma, which is overwritten.
+func vrfProofToHash(H hash.Hash, gamma *pointR1) []byte {
+	var str [paramB]byte
+	gamma.double()
+	gamma.double()
+	gamma.double()
+	_ = gamma.ToBytes(str[:])
+	H.Reset()
+	_, _ = H.Write([]byte{vrfSuite, 0x03})
+	_, _ = H.Write(str[:])
+	_, _ = H.Write([]byte{0x00})
+	return H.Sum(nil)
+}
@@ -0,0 +1,45 @@
+package ed25519
+
+import (
+	"crypto/sha512"
+	"hash"
+	"strconv"
+)
+
+// SignWithHash signs the message with privateKey as Sign does, but using
+// the hash function returned by newHash instead of SHA-512 for computing the
+// nonce and the challenge. The key pair is unchanged: the secret scalar is
+// still derived from the seed with SHA-512, so the public key is the one of
+// privateKey.
+//
+// This is an experimental, non-standard variant of EdDSA, not compatible
+// with RFC 8032: its signatures are only accepted by VerifyWithHash with the
+// same hash function. It will panic if len(privateKey) is not
+// PrivateKeySize, or if the hash size is not 64 bytes.
+func SignWithHash(newHash func() hash.Hash, privateKey PrivateKey, message []byte) []byte {
+	if l := len(privateKey); l != PrivateKeySize {
+		panic("ed25519: bad private key length: " + strconv.Itoa(l))
+	}
+	H := newHash()
+	if s := H.Size(); s != 2*paramB {
+		panic("ed25519: bad hash size: " + strconv.Itoa(s))
+	}
+	var key expandedKey
+	key.expand(sha512.New(), privateKey)
+	signature := make([]byte, SignatureSize)
+	key.sign(H, signature, message, nil, false)
+	return signature
+}
+
+// VerifyWithHash returns true if the signature is a valid signature of the
+// message produced by SignWithHash with the same hash function. It returns
+// false if the hash size is not 64 bytes. See SignWithHash for the
+// interoperability caveats of this variant.
+func VerifyWithHash(newHash func() hash.Hash, public PublicKey, message, signature []byte) bool {
+	H := newHash()
+	if H.Size() != 2*paramB {
+		return false
+	}
+	var v VerifyContext
+	return v.verifyDom(public, message, signature, H)
+}
@@ -0,0 +1,38 @@
+package ed25519_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed25519"
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestSignWithHash(t *testing.T) {
+	newBlake2b := func() hash.Hash { h, _ := blake2b.New512(nil); return h }
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	test.CheckNoErr(t, err, "failed to generate key")
+	msg := []byte("message")
+
+	sig := ed25519.SignWithHash(newBlake2b, priv, msg)
+	test.CheckOk(ed25519.VerifyWithHash(newBlake2b, pub, msg, sig), "VerifyWithHash failed", t)
+	test.CheckOk(!ed25519.Verify(pub, msg, sig), "Verify accepted a BLAKE2b signature", t)
+	test.CheckOk(!ed25519.VerifyWithHash(sha512.New, pub, msg, sig), "wrong hash accepted", t)
+	test.CheckOk(!ed25519.VerifyWithHash(newBlake2b, pub, []byte("other"), sig), "wrong message accepted", t)
+
+	// With SHA-512, it is the standard Ed25519.
+	std := ed25519.SignWithHash(sha512.New, priv, msg)
+	if want := ed25519.Sign(priv, msg); !bytes.Equal(std, want) {
+		test.ReportError(t, std, want)
+	}
+	test.CheckOk(ed25519.VerifyWithHash(sha512.New, pub, msg, std), "VerifyWithHash failed", t)
+
+	test.CheckOk(!ed25519.VerifyWithHash(sha256.New, pub, msg, sig), "short hash accepted", t)
+	err = test.CheckPanic(func() { ed25519.SignWithHash(sha256.New, priv, msg) })
+	test.CheckNoErr(t, err, "SignWithHash should panic on a short hash")
+}